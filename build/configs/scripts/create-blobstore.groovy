@@ -17,6 +17,26 @@ import groovy.json.JsonSlurper
 parsed_args = new JsonSlurper().parseText(args)
 
 existingBlobStore = blobStore.getBlobStoreManager().get(parsed_args.name)
-if (existingBlobStore == null) {
+if (parsed_args.type == 's3') {
+    config = [
+        bucket         : parsed_args.bucket,
+        region         : parsed_args.region,
+        prefix         : parsed_args.prefix ?: '',
+        expiration     : parsed_args.expiration,
+        accessKeyId    : parsed_args.access_key_id ?: '',
+        secretAccessKey: parsed_args.secret_access_key ?: '',
+        sessionToken   : parsed_args.session_token ?: '',
+        endpoint       : parsed_args.endpoint ?: '',
+        forcepathstyle : parsed_args.force_path_style ?: 'false'
+    ]
+    if (existingBlobStore == null) {
+        blobStore.createS3BlobStore(parsed_args.name, config)
+    } else {
+        configuration = existingBlobStore.getBlobStoreConfiguration().copy(parsed_args.name)
+        s3 = configuration.attributes('s3')
+        config.each { key, value -> s3.set(key, value) }
+        blobStore.getBlobStoreManager().update(configuration)
+    }
+} else if (existingBlobStore == null) {
     blobStore.createFileBlobStore(parsed_args.name, parsed_args.path)
 }
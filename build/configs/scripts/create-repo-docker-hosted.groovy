@@ -0,0 +1,45 @@
+/* Copyright 2020 EPAM Systems.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+See the License for the specific language governing permissions and
+limitations under the License. */
+
+import groovy.json.JsonSlurper
+import org.sonatype.nexus.repository.config.Configuration
+import org.sonatype.nexus.repository.config.WritePolicy
+
+parsed_args = new JsonSlurper().parseText(args)
+
+docker = [
+        httpPort: parsed_args.http_port == null ? null : Integer.valueOf(parsed_args.http_port),
+        v1Enabled: Boolean.valueOf(parsed_args.v1_enabled),
+        forceBasicAuth: Boolean.valueOf(parsed_args.force_basic_auth)
+]
+
+configuration = repository.createHosted(parsed_args.name,
+                                  'docker-hosted',
+                                  parsed_args.blob_store,
+                                  WritePolicy.valueOf(parsed_args.write_policy.toUpperCase()),
+                                  Boolean.valueOf(parsed_args.strict_content_validation))
+
+configuration.attributes['docker'] = docker
+
+def existingRepository = repository.getRepositoryManager().get(parsed_args.name)
+
+if (existingRepository != null) {
+    existingRepository.stop()
+    configuration.attributes['storage']['blobStoreName'] = existingRepository.configuration.attributes['storage']['blobStoreName']
+    configuration.entityMetadata=existingRepository.configuration.entityMetadata
+    existingRepository.update(configuration)
+    existingRepository.start()
+} else {
+    repository.getRepositoryManager().create(configuration)
+}
@@ -0,0 +1,72 @@
+/* Copyright 2020 EPAM Systems.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+See the License for the specific language governing permissions and
+limitations under the License. */
+
+import groovy.json.JsonSlurper
+import org.sonatype.nexus.repository.config.Configuration
+
+parsed_args = new JsonSlurper().parseText(args)
+
+docker = [
+        httpPort: parsed_args.http_port == null ? null : Integer.valueOf(parsed_args.http_port),
+        v1Enabled: Boolean.valueOf(parsed_args.v1_enabled),
+        forceBasicAuth: Boolean.valueOf(parsed_args.force_basic_auth)
+]
+
+configuration = repository.createProxy(parsed_args.name,
+                      'docker-proxy',
+                      parsed_args.remote_url,
+                      parsed_args.blob_store,
+                      Boolean.valueOf(parsed_args.strict_content_validation))
+
+configuration.setAttributes(
+        [
+                docker: docker,
+                dockerProxy: [
+                        indexType: parsed_args.index_type,
+                        indexUrl: parsed_args.index_url
+                ],
+                proxy  : [
+                        remoteUrl: parsed_args.remote_url,
+                        contentMaxAge: 1440.0,
+                        metadataMaxAge: 1440.0
+                ],
+                httpclient: [
+                        blocked: false,
+                        autoBlock: true,
+                        connection: [
+                                useTrustStore: false
+                        ]
+                ],
+                storage: [
+                        blobStoreName: parsed_args.blob_store,
+                        strictContentTypeValidation: Boolean.valueOf(parsed_args.strict_content_validation)
+                ],
+                negativeCache: [
+                        enabled: true,
+                        timeToLive: 1440.0
+                ]
+        ]
+)
+
+def existingRepository = repository.getRepositoryManager().get(parsed_args.name)
+
+if (existingRepository != null) {
+    existingRepository.stop()
+    configuration.attributes['storage']['blobStoreName'] = existingRepository.configuration.attributes['storage']['blobStoreName']
+    configuration.entityMetadata=existingRepository.configuration.entityMetadata
+    existingRepository.update(configuration)
+    existingRepository.start()
+} else {
+    repository.getRepositoryManager().create(configuration)
+}
@@ -0,0 +1,29 @@
+/* Copyright 2020 EPAM Systems.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+See the License for the specific language governing permissions and
+limitations under the License. */
+
+import groovy.json.JsonSlurper
+import org.sonatype.nexus.scheduling.TaskInfo
+import org.sonatype.nexus.scheduling.TaskScheduler
+
+parsed_args = new JsonSlurper().parseText(args)
+
+TaskScheduler taskScheduler = container.lookup(TaskScheduler.class.getName())
+
+TaskInfo existingTask = taskScheduler.listsTasks().find { TaskInfo taskInfo ->
+    taskInfo.name == parsed_args.name
+}
+
+if (existingTask && !existingTask.remove()) {
+    throw new RuntimeException("Could not remove currently running task : " + parsed_args.name)
+}
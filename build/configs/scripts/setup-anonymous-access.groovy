@@ -13,7 +13,17 @@ See the License for the specific language governing permissions and
 limitations under the License. */
 
 import groovy.json.JsonSlurper
+import org.sonatype.nexus.security.anonymous.AnonymousManager
 
 parsed_args = new JsonSlurper().parseText(args)
 
-security.setAnonymousAccess(Boolean.valueOf(parsed_args.anonymous_access))
+def anonymousManager = container.lookup(AnonymousManager.class.getName())
+def config = anonymousManager.getConfiguration()
+config.setEnabled(Boolean.valueOf(parsed_args.anonymous_access))
+if (parsed_args.user_id) {
+    config.setUserId(parsed_args.user_id)
+}
+if (parsed_args.realm_name) {
+    config.setRealmName(parsed_args.realm_name)
+}
+anonymousManager.setConfiguration(config)
@@ -0,0 +1,53 @@
+/* Copyright 2020 EPAM Systems.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+See the License for the specific language governing permissions and
+limitations under the License. */
+
+import groovy.json.JsonSlurper
+import org.sonatype.nexus.cleanup.storage.CleanupPolicy
+import org.sonatype.nexus.cleanup.storage.CleanupPolicyStorage
+
+import java.util.concurrent.TimeUnit
+
+parsed_args = new JsonSlurper().parseText(args)
+
+CleanupPolicyStorage cleanupPolicyStorage = container.lookup(CleanupPolicyStorage.class.getName())
+
+Map<String, String> criteria = [:]
+if (parsed_args.last_blob_updated) {
+    criteria.put('lastBlobUpdated', TimeUnit.DAYS.toSeconds(parsed_args.last_blob_updated as Long).toString())
+}
+if (parsed_args.last_downloaded) {
+    criteria.put('lastDownloaded', TimeUnit.DAYS.toSeconds(parsed_args.last_downloaded as Long).toString())
+}
+if (parsed_args.release_type) {
+    criteria.put('isPrerelease', (parsed_args.release_type == 'PRERELEASES').toString())
+}
+if (parsed_args.asset_regex) {
+    criteria.put('regex', parsed_args.asset_regex)
+}
+
+if (cleanupPolicyStorage.exists(parsed_args.name)) {
+    CleanupPolicy policy = cleanupPolicyStorage.get(parsed_args.name)
+    policy.setNotes(parsed_args.notes)
+    policy.setFormat(parsed_args.format)
+    policy.setCriteria(criteria)
+    cleanupPolicyStorage.update(policy)
+} else {
+    CleanupPolicy policy = cleanupPolicyStorage.newCleanupPolicy()
+    policy.setName(parsed_args.name)
+    policy.setNotes(parsed_args.notes)
+    policy.setFormat(parsed_args.format)
+    policy.setMode('delete')
+    policy.setCriteria(criteria)
+    cleanupPolicyStorage.add(policy)
+}
@@ -0,0 +1,39 @@
+/* Copyright 2020 EPAM Systems.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+See the License for the specific language governing permissions and
+limitations under the License. */
+
+import groovy.json.JsonSlurper
+import org.sonatype.nexus.selector.SelectorConfiguration
+import org.sonatype.nexus.selector.SelectorManager
+
+parsed_args = new JsonSlurper().parseText(args)
+
+SelectorManager selectorManager = container.lookup(SelectorManager.class.getName())
+
+SelectorConfiguration existingSelector = selectorManager.browse().find { SelectorConfiguration selector ->
+    selector.name == parsed_args.name
+}
+
+if (existingSelector) {
+    existingSelector.setDescription(parsed_args.description)
+    existingSelector.setAttributes(['expression': parsed_args.expression])
+    selectorManager.update(existingSelector)
+} else {
+    SelectorConfiguration selector = new SelectorConfiguration(
+            name: parsed_args.name,
+            type: 'csel',
+            description: parsed_args.description,
+            attributes: ['expression': parsed_args.expression]
+    )
+    selectorManager.create(selector)
+}
@@ -40,24 +40,37 @@ ldapConfig.setName(parsed_args.name)
 // Connection
 connection = new Connection()
 connection.setHost(new Connection.Host(Connection.Protocol.valueOf(parsed_args.protocol), parsed_args.hostname, Integer.valueOf(parsed_args.port)))
-connection.setAuthScheme("none")
+connection.setAuthScheme(parsed_args.auth_scheme ?: "none")
+if (parsed_args.auth_realm) {
+    connection.setSaslRealm(parsed_args.auth_realm)
+}
+if (parsed_args.auth_username) {
+    connection.setSystemUsername(parsed_args.auth_username)
+    connection.setSystemPassword(parsed_args.auth_password)
+}
+connection.setUseTrustStore(Boolean.valueOf(parsed_args.use_trust_store ?: false))
 connection.setSearchBase(parsed_args.search_base)
-connection.setConnectionTimeout(30)
-connection.setConnectionRetryDelay(300)
-connection.setMaxIncidentsCount(3)
+connection.setConnectionTimeout(Integer.valueOf(parsed_args.connection_timeout ?: 30))
+connection.setConnectionRetryDelay(Integer.valueOf(parsed_args.connection_retry_delay ?: 300))
+connection.setMaxIncidentsCount(Integer.valueOf(parsed_args.max_incidents_count ?: 3))
 ldapConfig.setConnection(connection)
 
 
 // Mapping
 mapping = new Mapping()
 mapping.setUserBaseDn(parsed_args.user_base_dn)
+mapping.setUserSubtree(Boolean.valueOf(parsed_args.user_subtree ?: false))
+mapping.setLdapFilter(parsed_args.user_ldap_filter)
 mapping.setUserObjectClass(parsed_args.user_object_class)
 mapping.setUserIdAttribute(parsed_args.user_id_attribute)
 mapping.setUserRealNameAttribute(parsed_args.user_real_name_attribute)
 mapping.setEmailAddressAttribute(parsed_args.user_email_attribute)
+mapping.setUserPasswordAttribute(parsed_args.user_password_attribute)
+mapping.setUserMemberOfAttribute(parsed_args.user_member_of_attribute)
 
-mapping.setLdapGroupsAsRoles(true)
+mapping.setLdapGroupsAsRoles(parsed_args.ldap_groups_as_roles != null ? Boolean.valueOf(parsed_args.ldap_groups_as_roles) : true)
 mapping.setGroupBaseDn(parsed_args.group_base_dn)
+mapping.setGroupSubtree(Boolean.valueOf(parsed_args.group_subtree ?: false))
 mapping.setGroupObjectClass(parsed_args.group_object_class)
 mapping.setGroupIdAttribute(parsed_args.group_id_attribute)
 mapping.setGroupMemberAttribute(parsed_args.group_member_attribute)
@@ -37,11 +37,20 @@ try {
 
 privilege.setDescription(parsed_args.description)
 privilege.setType(parsed_args.type)
-privilege.setProperties([
-        'format': parsed_args.format,
-        'repository': parsed_args.repository,
-        'actions': parsed_args.actions.join(',')
-] as Map<String, String>)
+Map<String, String> properties = ['actions': parsed_args.actions.join(',')]
+switch (parsed_args.type) {
+    case 'repository-content-selector':
+        properties.contentSelector = parsed_args.content_selector
+        properties.repository = parsed_args.repository
+        break
+    case 'script':
+        properties.name = parsed_args.script_name
+        break
+    default:
+        properties.format = parsed_args.format
+        properties.repository = parsed_args.repository
+}
+privilege.setProperties(properties)
 
 if (update) {
     authManager.updatePrivilege(privilege)
@@ -17,6 +17,7 @@ require (
 	github.com/openshift/client-go v3.9.0+incompatible
 	github.com/operator-framework/operator-sdk v0.0.0-20190530173525-d6f9cdf2f52e
 	github.com/pkg/errors v0.8.1
+	github.com/prometheus/client_golang v1.1.0
 	github.com/spf13/pflag v1.0.3
 	gopkg.in/resty.v1 v1.12.0
 	k8s.io/api v0.0.0-20190222213804-5cb15d344471
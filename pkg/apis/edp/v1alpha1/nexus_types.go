@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"time"
 )
@@ -15,15 +16,305 @@ type NexusSpec struct {
 	KeycloakSpec     KeycloakSpec                     `json:"keycloakSpec,omitempty"`
 	ImagePullSecrets []coreV1Api.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 	Image            string                           `json:"image"`
-	Version          string                           `json:"version"`
-	BasePath         string                           `json:"basePath"`
-	Volumes          []NexusVolumes                   `json:"volumes,omitempty"`
-	Users            []NexusUsers                     `json:"users,omitempty"`
-	EdpSpec          EdpSpec                          `json:"edpSpec"`
+	// Version of Nexus image. Change of version upgrades Nexus, data volume is archived to <name>-backup volume
+	// before the new version is rolled out. S3 blob stores are not backed up, they have to be backed up separately
+	Version  string         `json:"version"`
+	BasePath string         `json:"basePath"`
+	Volumes  []NexusVolumes `json:"volumes,omitempty"`
+	// Users are created with random initial password, it is saved to Secret <name>-user-<username>
+	Users   []NexusUsers `json:"users,omitempty"`
+	EdpSpec EdpSpec      `json:"edpSpec"`
+	// VolumeRetentionPolicy defines whether volumes are deleted (Delete, default) or kept (Retain) with Nexus CR
+	VolumeRetentionPolicy VolumeRetentionPolicy `json:"volumeRetentionPolicy,omitempty"`
+	// Resources of Nexus container, memory request of 500Mi is used if not set
+	Resources *coreV1Api.ResourceRequirements `json:"resources,omitempty"`
+	// LivenessProbe overrides parameters of default liveness probe of Nexus container
+	LivenessProbe *NexusProbe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe overrides parameters of default readiness probe of Nexus container
+	ReadinessProbe *NexusProbe `json:"readinessProbe,omitempty"`
+	// Env is a list of additional environment variables of Nexus container
+	Env []coreV1Api.EnvVar `json:"env,omitempty"`
+	// JvmOptions are passed to Nexus through INSTALL4J_ADD_VM_PARAMS, defaults of the image are used if not set
+	JvmOptions *NexusJvmOptions `json:"jvmOptions,omitempty"`
+	// NodeSelector of Nexus pod
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of Nexus pod, they are applied to backup Job as well since it runs on the node of Nexus pod
+	Tolerations []coreV1Api.Toleration `json:"tolerations,omitempty"`
+	// Affinity of Nexus pod
+	Affinity *coreV1Api.Affinity `json:"affinity,omitempty"`
+	// PriorityClassName of Nexus pod
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// PodAnnotations are added to Nexus pod
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// PodLabels are added to Nexus pod, app label can not be overridden
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+	// AuthProxy configures authentication proxy which is placed in front of Nexus if Keycloak integration is enabled
+	AuthProxy *NexusAuthProxy `json:"authProxy,omitempty"`
+	// Ldap configures LDAP server which is used by LdapRealm to authenticate users, LdapRealm has to be listed
+	// in realms if they are set
+	Ldap *NexusLdap `json:"ldap,omitempty"`
+	// Realms are activated in the given order and the rest of realms are deactivated.
+	// NexusAuthenticatingRealm is always active since it is used by operator, rutauth-realm has to be listed
+	// if Keycloak integration is enabled. Realms require realms REST API of Nexus
+	Realms []string `json:"realms,omitempty"`
+	// AnonymousAccess configures access of unauthenticated users, current settings are kept if it is not set
+	AnonymousAccess *NexusAnonymousAccess `json:"anonymousAccess,omitempty"`
+	// BlobStores are created in addition to the ones from blobs ConfigMap, existing blob stores are updated
+	BlobStores []NexusBlobStore `json:"blobStores,omitempty"`
+	// CleanupPolicies are created or updated in Nexus, policies which have been removed from spec are deleted
+	CleanupPolicies []NexusCleanupPolicy `json:"cleanupPolicies,omitempty"`
+	// CleanupTaskCron is a schedule of Cleanup service task which applies cleanup policies, 0 0 1 * * ? by default
+	CleanupTaskCron string `json:"cleanupTaskCron,omitempty"`
+	// Tasks are scheduled in addition to the ones from default-tasks ConfigMap, task from spec overrides the one from
+	// ConfigMap with the same name. Tasks which have been removed from both are deleted
+	Tasks []NexusTask `json:"tasks,omitempty"`
+	// ContentSelectors are created or updated in Nexus, selectors which have been removed from spec are deleted
+	ContentSelectors []NexusContentSelector `json:"contentSelectors,omitempty"`
+	// Privileges are created or updated in Nexus and can be granted by roles from default-roles ConfigMap.
+	// Privileges which have been removed from spec are deleted
+	Privileges []NexusPrivilege `json:"privileges,omitempty"`
+	// UserRemovalPolicy defines whether users which have been removed from spec are disabled (Disable, default)
+	// or deleted (Delete). Disable is the default since deleted user can't be restored: password changed by the user and
+	// roles granted outside of spec are lost, while disabled user is enabled again when it is returned to spec
+	UserRemovalPolicy UserRemovalPolicy `json:"userRemovalPolicy,omitempty"`
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
 }
 
+// VolumeRetentionPolicy describes what happens to Nexus volumes when Nexus CR is deleted
+type VolumeRetentionPolicy string
+
+const (
+	VolumeRetentionPolicyDelete VolumeRetentionPolicy = "Delete"
+	VolumeRetentionPolicyRetain VolumeRetentionPolicy = "Retain"
+)
+
+// UserRemovalPolicy describes what happens to Nexus users when they are removed from spec
+type UserRemovalPolicy string
+
+const (
+	UserRemovalPolicyDisable UserRemovalPolicy = "Disable"
+	UserRemovalPolicyDelete  UserRemovalPolicy = "Delete"
+)
+
+// NexusProbe defines parameters of Nexus container probe, zero values are replaced with defaults
+type NexusProbe struct {
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+	TimeoutSeconds      int32 `json:"timeoutSeconds,omitempty"`
+	PeriodSeconds       int32 `json:"periodSeconds,omitempty"`
+	SuccessThreshold    int32 `json:"successThreshold,omitempty"`
+	FailureThreshold    int32 `json:"failureThreshold,omitempty"`
+}
+
+// NexusJvmOptions defines memory settings and additional options of Nexus JVM
+type NexusJvmOptions struct {
+	// HeapSize is used for both initial and maximum heap size, e.g. 2703m or 4g
+	HeapSize string `json:"heapSize,omitempty"`
+	// MaxDirectMemorySize is a limit of direct memory, e.g. 2703m or 4g
+	MaxDirectMemorySize string   `json:"maxDirectMemorySize,omitempty"`
+	Options             []string `json:"options,omitempty"`
+}
+
+// AuthProxyType is an implementation of authentication proxy
+type AuthProxyType string
+
+const (
+	AuthProxyGatekeeper          AuthProxyType = "gatekeeper"
+	AuthProxyOAuth2Proxy         AuthProxyType = "oauth2-proxy"
+	AuthProxyOpenshiftOAuthProxy AuthProxyType = "openshift-oauth-proxy"
+)
+
+// NexusAuthProxy defines authentication proxy sidecar of Nexus
+type NexusAuthProxy struct {
+	// Type of proxy: gatekeeper (default), oauth2-proxy or openshift-oauth-proxy
+	Type AuthProxyType `json:"type,omitempty"`
+	// Image of proxy, default image of the proxy type is used if not set
+	Image string `json:"image,omitempty"`
+	// Roles which are allowed to access Nexus, developer and administrator by default.
+	// OpenShift groups are expected for openshift-oauth-proxy, access is not restricted by default
+	Roles []string `json:"roles,omitempty"`
+	// ExtraArgs are appended to arguments of proxy
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// InsecureSkipVerify disables verification of Keycloak TLS certificate by oauth2-proxy, it is verified by default
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// NexusLdap defines connection to LDAP server and mapping of LDAP users and groups
+type NexusLdap struct {
+	// Name of LDAP server in Nexus, ldap by default
+	Name string `json:"name,omitempty"`
+	// Protocol is ldap (default) or ldaps
+	Protocol string `json:"protocol,omitempty"`
+	Host     string `json:"host"`
+	// Port is 389 for ldap and 636 for ldaps by default
+	Port int32 `json:"port,omitempty"`
+	// UseTrustStore enables Nexus truststore for ldaps connection
+	UseTrustStore bool   `json:"useTrustStore,omitempty"`
+	SearchBase    string `json:"searchBase"`
+	// AuthScheme is NONE, SIMPLE, DIGEST_MD5 or CRAM_MD5, SIMPLE is used by default if bind DN is set and NONE otherwise
+	AuthScheme string `json:"authScheme,omitempty"`
+	// AuthRealm is SASL realm of DIGEST_MD5 and CRAM_MD5 schemes
+	AuthRealm string `json:"authRealm,omitempty"`
+	// BindDn is a user which is used to search users and groups
+	BindDn string `json:"bindDn,omitempty"`
+	// BindPasswordSecretRef is a key of Secret in Nexus namespace which contains password of bind user
+	BindPasswordSecretRef *coreV1Api.SecretKeySelector `json:"bindPasswordSecretRef,omitempty"`
+	// ConnectionTimeoutSeconds is 30 seconds by default
+	ConnectionTimeoutSeconds int32 `json:"connectionTimeoutSeconds,omitempty"`
+	// ConnectionRetryDelaySeconds is 300 seconds by default
+	ConnectionRetryDelaySeconds int32 `json:"connectionRetryDelaySeconds,omitempty"`
+	// MaxIncidentsCount is 3 by default
+	MaxIncidentsCount int32                  `json:"maxIncidentsCount,omitempty"`
+	UserMapping       NexusLdapUserMapping   `json:"userMapping"`
+	GroupMapping      *NexusLdapGroupMapping `json:"groupMapping,omitempty"`
+}
+
+// NexusLdapUserMapping defines how Nexus users are found in LDAP
+type NexusLdapUserMapping struct {
+	BaseDn      string `json:"baseDn,omitempty"`
+	Subtree     bool   `json:"subtree,omitempty"`
+	ObjectClass string `json:"objectClass"`
+	LdapFilter  string `json:"ldapFilter,omitempty"`
+	// IdAttribute is e.g. uid or sAMAccountName for Active Directory
+	IdAttribute           string `json:"idAttribute"`
+	RealNameAttribute     string `json:"realNameAttribute"`
+	EmailAddressAttribute string `json:"emailAddressAttribute"`
+	// PasswordAttribute is used to authenticate users instead of bind if it is set
+	PasswordAttribute string `json:"passwordAttribute,omitempty"`
+}
+
+// NexusLdapGroupMapping defines how LDAP groups are mapped to Nexus roles
+type NexusLdapGroupMapping struct {
+	// Type is static (default) or dynamic, i.e. groups are taken from member of attribute of user
+	Type              string `json:"type,omitempty"`
+	BaseDn            string `json:"baseDn,omitempty"`
+	Subtree           bool   `json:"subtree,omitempty"`
+	ObjectClass       string `json:"objectClass,omitempty"`
+	IdAttribute       string `json:"idAttribute,omitempty"`
+	MemberAttribute   string `json:"memberAttribute,omitempty"`
+	MemberFormat      string `json:"memberFormat,omitempty"`
+	MemberOfAttribute string `json:"memberOfAttribute,omitempty"`
+}
+
+// NexusAnonymousAccess defines whether unauthenticated users are allowed and which user they act as
+type NexusAnonymousAccess struct {
+	Enabled bool `json:"enabled"`
+	// UserId is a user whose permissions are granted to unauthenticated users, anonymous by default
+	UserId string `json:"userId,omitempty"`
+	// RealmName is a realm of the user, NexusAuthorizingRealm by default
+	RealmName string `json:"realmName,omitempty"`
+}
+
+// BlobStoreType is a type of Nexus blob store
+type BlobStoreType string
+
+const (
+	BlobStoreTypeFile BlobStoreType = "File"
+	BlobStoreTypeS3   BlobStoreType = "S3"
+)
+
+// NexusBlobStore defines blob store of Nexus
+type NexusBlobStore struct {
+	Name string `json:"name"`
+	// Type of blob store is File (default) or S3, it can not be changed once blob store is created
+	Type BlobStoreType `json:"type,omitempty"`
+	// Path of File blob store, relative path is resolved against /nexus-data/blobs, name is used by default
+	Path string `json:"path,omitempty"`
+	// S3 defines bucket of S3 blob store
+	S3 *NexusS3BlobStore `json:"s3,omitempty"`
+	// SoftQuota raises alert when it is violated, writes to blob store are not blocked. It is removed if not set
+	SoftQuota *NexusBlobStoreSoftQuota `json:"softQuota,omitempty"`
+}
+
+// NexusBlobStoreSoftQuota defines limit of space used by blob store or of space remaining in it
+type NexusBlobStoreSoftQuota struct {
+	// Type is spaceUsedQuota (violated when used space exceeds limit) or spaceRemainingQuota
+	// (violated when available space falls below limit)
+	Type  string            `json:"type"`
+	Limit resource.Quantity `json:"limit"`
+}
+
+// NexusS3BlobStore defines bucket of S3 blob store and credentials to access it
+type NexusS3BlobStore struct {
+	Bucket string `json:"bucket"`
+	// Region of bucket, us-east-1 by default
+	Region string `json:"region,omitempty"`
+	// Prefix is prepended to keys of blobs in bucket
+	Prefix string `json:"prefix,omitempty"`
+	// Expiration is a number of days after which deleted blobs are removed from bucket, -1 disables removal.
+	// 3 days by default
+	Expiration *int32 `json:"expiration,omitempty"`
+	// Endpoint overrides AWS endpoint, e.g. URL of MinIO
+	Endpoint string `json:"endpoint,omitempty"`
+	// ForcePathStyle enables path-style access to bucket which is required by MinIO
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+	// CredentialsSecret is a Secret in Nexus namespace with accessKeyId, secretAccessKey and optional sessionToken keys.
+	// Credentials of Nexus node (e.g. IAM instance role) are used if it is not set
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// NexusTask defines scheduled task of Nexus
+type NexusTask struct {
+	Name string `json:"name"`
+	// TypeId of task, e.g. blobstore.compact or repository.rebuild-index
+	TypeId string `json:"typeId"`
+	// Cron is a schedule of task in Quartz format, e.g. 0 0 1 * * ?
+	Cron string `json:"cron"`
+	// Properties of task, e.g. blobstoreName of blobstore.compact task
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// NexusCleanupPolicy defines which components are deleted from repositories the policy is attached to.
+// At least one criterion has to be set
+type NexusCleanupPolicy struct {
+	Name  string `json:"name"`
+	Notes string `json:"notes,omitempty"`
+	// Format of repositories the policy can be attached to, e.g. maven or npm, all formats by default
+	Format string `json:"format,omitempty"`
+	// LastDownloadedDays removes components which have not been downloaded for the given number of days
+	LastDownloadedDays int32 `json:"lastDownloadedDays,omitempty"`
+	// LastBlobUpdatedDays removes components which have been published the given number of days ago
+	LastBlobUpdatedDays int32 `json:"lastBlobUpdatedDays,omitempty"`
+	// AssetNameRegex removes components which have an asset matching the regular expression
+	AssetNameRegex string `json:"assetNameRegex,omitempty"`
+	// ReleaseType removes only RELEASES or PRERELEASES (e.g. maven snapshots), it is supported by maven and npm
+	ReleaseType string `json:"releaseType,omitempty"`
+}
+
+// NexusContentSelector selects content of repositories with CSEL expression
+type NexusContentSelector struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Expression in CSEL, e.g. format == "maven2" and path =^ "/com/acme/team-a/"
+	Expression string `json:"expression"`
+}
+
+// PrivilegeType is a type of privilege managed by operator
+type PrivilegeType string
+
+const (
+	PrivilegeTypeRepositoryView            PrivilegeType = "repository-view"
+	PrivilegeTypeRepositoryContentSelector PrivilegeType = "repository-content-selector"
+	PrivilegeTypeScript                    PrivilegeType = "script"
+)
+
+// NexusPrivilege grants actions on repositories, content of repositories matched by content selector or scripts
+type NexusPrivilege struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Type        PrivilegeType `json:"type"`
+	// Actions granted by privilege: BROWSE, READ, EDIT, ADD, DELETE for repositories, RUN for scripts, or ALL
+	Actions []string `json:"actions"`
+	// Format of repositories, * matches all formats. It is mandatory for repository privileges
+	Format string `json:"format,omitempty"`
+	// Repository name, * matches all repositories. It is mandatory for repository privileges
+	Repository string `json:"repository,omitempty"`
+	// ContentSelector is a name of content selector, it is mandatory for repository-content-selector privileges
+	ContentSelector string `json:"contentSelector,omitempty"`
+	// ScriptName is a name of script, * matches all scripts. It is mandatory for script privileges
+	ScriptName string `json:"scriptName,omitempty"`
+}
+
 type EdpSpec struct {
 	DnsWildcard string `json:"dnsWildcard"`
 }
@@ -48,13 +339,117 @@ type NexusStatus struct {
 	Available       bool      `json:"available,omitempty"`
 	LastTimeUpdated time.Time `json:"lastTimeUpdated,omitempty"`
 	Status          string    `json:"status,omitempty"`
+	// CurrentVersion is the version of deployed Nexus, differs from spec version until upgrade is done
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// RejectedVersion is the spec version upgrade to which has failed validation, upgrade is not retried until
+	// spec version changes and the current version stays deployed
+	RejectedVersion string `json:"rejectedVersion,omitempty"`
+	// ObservedGeneration is the generation of Nexus CR which has been reconciled successfully
+	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
+	Conditions         []NexusCondition `json:"conditions,omitempty"`
+	// BlobStores is the usage of blob stores of Nexus, it is refreshed periodically
+	BlobStores []NexusBlobStoreStatus `json:"blobStores,omitempty"`
+	// Tasks is the state of scheduled tasks created by operator, it is refreshed periodically
+	Tasks []NexusTaskStatus `json:"tasks,omitempty"`
+	// TaskRun is the state of the last task run requested with run-task annotation
+	TaskRun *NexusTaskRunStatus `json:"taskRun,omitempty"`
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
 }
 
+// NexusBlobStoreStatus describes usage of blob store
+type NexusBlobStoreStatus struct {
+	Name                  string `json:"name"`
+	Type                  string `json:"type"`
+	BlobCount             int64  `json:"blobCount"`
+	TotalSizeInBytes      int64  `json:"totalSizeInBytes"`
+	AvailableSpaceInBytes int64  `json:"availableSpaceInBytes"`
+	// SoftQuota is set if soft quota is enabled for blob store
+	SoftQuota *NexusBlobStoreSoftQuotaStatus `json:"softQuota,omitempty"`
+}
+
+// NexusBlobStoreSoftQuotaStatus describes soft quota of blob store and whether it is violated
+type NexusBlobStoreSoftQuotaStatus struct {
+	Type         string `json:"type"`
+	LimitInBytes int64  `json:"limitInBytes"`
+	Violated     bool   `json:"violated"`
+	Message      string `json:"message,omitempty"`
+}
+
+// NexusTaskStatus describes state of scheduled task and result of its last run
+type NexusTaskStatus struct {
+	Name          string `json:"name"`
+	Type          string `json:"type,omitempty"`
+	CurrentState  string `json:"currentState,omitempty"`
+	LastRun       string `json:"lastRun,omitempty"`
+	LastRunResult string `json:"lastRunResult,omitempty"`
+	NextRun       string `json:"nextRun,omitempty"`
+}
+
+// TaskRunState is a state of task run requested with run-task annotation
+type TaskRunState string
+
+const (
+	// TaskRunStatePending - task run is recorded but it is not confirmed that task has been started yet
+	TaskRunStatePending   TaskRunState = "Pending"
+	TaskRunStateRunning   TaskRunState = "Running"
+	TaskRunStateSucceeded TaskRunState = "Succeeded"
+	TaskRunStateFailed    TaskRunState = "Failed"
+)
+
+// NexusTaskRunStatus describes task run requested with run-task annotation
+type NexusTaskRunStatus struct {
+	Name  string       `json:"name"`
+	State TaskRunState `json:"state"`
+	// PreviousLastRun is the time of task run which preceded the requested one, it is used to detect completion
+	PreviousLastRun string       `json:"previousLastRun,omitempty"`
+	StartTime       metav1.Time  `json:"startTime,omitempty"`
+	CompletionTime  *metav1.Time `json:"completionTime,omitempty"`
+	// Result is the last run result reported by Nexus
+	Result  string `json:"result,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// NexusConditionType is a type of Nexus condition
+type NexusConditionType string
+
+const (
+	NexusConditionInstalled            NexusConditionType = "Installed"
+	NexusConditionDeploymentReady      NexusConditionType = "DeploymentReady"
+	NexusConditionConfigured           NexusConditionType = "Configured"
+	NexusConditionConfigurationExposed NexusConditionType = "ConfigurationExposed"
+	NexusConditionIntegrated           NexusConditionType = "Integrated"
+	NexusConditionDegraded             NexusConditionType = "Degraded"
+	// NexusConditionLdapReachable only means that LDAP server accepts TCP (or TLS) connections,
+	// bind credentials and search settings are not checked
+	NexusConditionLdapReachable NexusConditionType = "LdapReachable"
+)
+
+// NexusCondition describes the state of Nexus at a certain point
+type NexusCondition struct {
+	Type               NexusConditionType        `json:"type"`
+	Status             coreV1Api.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time               `json:"lastTransitionTime,omitempty"`
+	Reason             string                    `json:"reason,omitempty"`
+	Message            string                    `json:"message,omitempty"`
+}
+
 type KeycloakSpec struct {
 	Enabled bool   `json:"enabled, omitempty"`
 	Url     string `json:"url, omitempty"`
+	// RoleMappings grant Nexus roles to users with Keycloak roles. They require gatekeeper authentication proxy,
+	// which passes user id and roles in X-Auth-Userid and X-Auth-Roles headers, and rutauth-realm in realms if
+	// realms are set, otherwise the realm is enabled by operator
+	RoleMappings []KeycloakRoleMapping `json:"roleMappings,omitempty"`
+}
+
+// KeycloakRoleMapping maps Keycloak role to Nexus roles through external role mapping, i.e. Nexus role with id
+// of Keycloak role which includes the mapped Nexus roles
+type KeycloakRoleMapping struct {
+	// KeycloakRole is a name of Keycloak realm role or <client>:<role> for client role. It can't be a built-in
+	// Nexus role or a role from default-roles ConfigMap since Nexus role with the same id is created for mapping
+	KeycloakRole string   `json:"keycloakRole"`
+	NexusRoles   []string `json:"nexusRoles"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,122 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// NexusRepositorySpec defines the desired state of NexusRepository
+// +k8s:openapi-gen=true
+type NexusRepositorySpec struct {
+	// OwnerName is the name of the Nexus CR in the same namespace that hosts the repository
+	OwnerName string `json:"ownerName"`
+	// Name of the repository in Nexus, defaults to the name of the CR
+	Name string `json:"name,omitempty"`
+	// Format of the repository: maven, npm, nuget, docker, pypi, helm, raw, go, apt or yum
+	Format string `json:"format"`
+	// Type of the repository: hosted, proxy or group
+	Type                    string                `json:"type"`
+	BlobStore               string                `json:"blobStore,omitempty"`
+	StrictContentValidation *bool                 `json:"strictContentValidation,omitempty"`
+	WritePolicy             string                `json:"writePolicy,omitempty"`
+	VersionPolicy           string                `json:"versionPolicy,omitempty"`
+	LayoutPolicy            string                `json:"layoutPolicy,omitempty"`
+	Proxy                   *NexusRepositoryProxy `json:"proxy,omitempty"`
+	Group                   *NexusRepositoryGroup `json:"group,omitempty"`
+	// Docker configures registry of docker repository
+	Docker *NexusRepositoryDocker `json:"docker,omitempty"`
+	// Raw configures how content of raw repository is served
+	Raw *NexusRepositoryRaw `json:"raw,omitempty"`
+	// Apt configures distribution and signing of apt repository
+	Apt *NexusRepositoryApt `json:"apt,omitempty"`
+	// Yum configures metadata and signing of yum repository
+	Yum *NexusRepositoryYum `json:"yum,omitempty"`
+	// CleanupPolicies are names of cleanup policies from Nexus spec which are applied to hosted or proxy repository
+	CleanupPolicies []string `json:"cleanupPolicies,omitempty"`
+}
+
+type NexusRepositoryProxy struct {
+	RemoteUrl string `json:"remoteUrl"`
+}
+
+type NexusRepositoryGroup struct {
+	MemberNames []string `json:"memberNames"`
+}
+
+// NexusRepositoryDocker defines docker registry which is served on a dedicated connector port of Nexus
+type NexusRepositoryDocker struct {
+	// HttpPort of repository connector, the port is added to Nexus Service and exposed by Ingress or Route
+	HttpPort int32 `json:"httpPort,omitempty"`
+	// Host of Ingress or Route of registry, <nexus>-<repository CR>-<namespace>.<dnsWildcard> by default
+	Host string `json:"host,omitempty"`
+	// ForceBasicAuth disallows anonymous pull, true by default
+	ForceBasicAuth *bool `json:"forceBasicAuth,omitempty"`
+	V1Enabled      bool  `json:"v1Enabled,omitempty"`
+	// IndexType of proxy repository: REGISTRY, HUB or CUSTOM, HUB is used by default for Docker Hub and REGISTRY otherwise
+	IndexType string `json:"indexType,omitempty"`
+	// IndexUrl of proxy repository with CUSTOM index type
+	IndexUrl string `json:"indexUrl,omitempty"`
+}
+
+// NexusRepositoryRaw defines settings of raw repository
+type NexusRepositoryRaw struct {
+	// ContentDisposition header of served files: ATTACHMENT (default) or INLINE
+	ContentDisposition string `json:"contentDisposition,omitempty"`
+}
+
+// NexusRepositoryApt defines settings of apt repository
+type NexusRepositoryApt struct {
+	// Distribution of packages, e.g. bionic
+	Distribution string `json:"distribution"`
+	// Flat is set for proxy of repository with flat layout
+	Flat bool `json:"flat,omitempty"`
+	// SigningSecret is a name of Secret with GPG keypair (keypair key) and its passphrase (passphrase key),
+	// it is mandatory for hosted repository. Repository is updated when Secret changes
+	SigningSecret string `json:"signingSecret,omitempty"`
+}
+
+// NexusRepositoryYum defines settings of yum repository
+type NexusRepositoryYum struct {
+	// RepodataDepth is a depth of directories where repodata is created in hosted repository
+	RepodataDepth int32 `json:"repodataDepth,omitempty"`
+	// DeployPolicy of hosted repository: STRICT (default) or PERMISSIVE
+	DeployPolicy string `json:"deployPolicy,omitempty"`
+	// SigningSecret is a name of Secret with GPG keypair (keypair key) and its passphrase (passphrase key)
+	// which signs metadata of proxy and group repositories, it can't be set for hosted repository.
+	// Repository is updated when Secret changes
+	SigningSecret string `json:"signingSecret,omitempty"`
+}
+
+// NexusRepositoryStatus defines the observed state of NexusRepository
+// +k8s:openapi-gen=true
+type NexusRepositoryStatus struct {
+	Available       bool      `json:"available,omitempty"`
+	LastTimeUpdated time.Time `json:"lastTimeUpdated,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	DetailedMessage string    `json:"detailedMessage,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NexusRepository is the Schema for the nexusrepositories API
+// +k8s:openapi-gen=true
+type NexusRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NexusRepositorySpec   `json:"spec,omitempty"`
+	Status NexusRepositoryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NexusRepositoryList contains a list of NexusRepository
+type NexusRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NexusRepository `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NexusRepository{}, &NexusRepositoryList{})
+}
@@ -25,9 +25,37 @@ func (in *EdpSpec) DeepCopy() *EdpSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRoleMapping) DeepCopyInto(out *KeycloakRoleMapping) {
+	*out = *in
+	if in.NexusRoles != nil {
+		in, out := &in.NexusRoles, &out.NexusRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRoleMapping.
+func (in *KeycloakRoleMapping) DeepCopy() *KeycloakRoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
 	*out = *in
+	if in.RoleMappings != nil {
+		in, out := &in.RoleMappings, &out.RoleMappings
+		*out = make([]KeycloakRoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -69,6 +97,256 @@ func (in *Nexus) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusAnonymousAccess) DeepCopyInto(out *NexusAnonymousAccess) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusAnonymousAccess.
+func (in *NexusAnonymousAccess) DeepCopy() *NexusAnonymousAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusAnonymousAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusAuthProxy) DeepCopyInto(out *NexusAuthProxy) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusAuthProxy.
+func (in *NexusAuthProxy) DeepCopy() *NexusAuthProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusAuthProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusBlobStore) DeepCopyInto(out *NexusBlobStore) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(NexusS3BlobStore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SoftQuota != nil {
+		in, out := &in.SoftQuota, &out.SoftQuota
+		*out = new(NexusBlobStoreSoftQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusBlobStore.
+func (in *NexusBlobStore) DeepCopy() *NexusBlobStore {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusBlobStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusBlobStoreSoftQuota) DeepCopyInto(out *NexusBlobStoreSoftQuota) {
+	*out = *in
+	out.Limit = in.Limit.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusBlobStoreSoftQuota.
+func (in *NexusBlobStoreSoftQuota) DeepCopy() *NexusBlobStoreSoftQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusBlobStoreSoftQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusBlobStoreSoftQuotaStatus) DeepCopyInto(out *NexusBlobStoreSoftQuotaStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusBlobStoreSoftQuotaStatus.
+func (in *NexusBlobStoreSoftQuotaStatus) DeepCopy() *NexusBlobStoreSoftQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusBlobStoreSoftQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusBlobStoreStatus) DeepCopyInto(out *NexusBlobStoreStatus) {
+	*out = *in
+	if in.SoftQuota != nil {
+		in, out := &in.SoftQuota, &out.SoftQuota
+		*out = new(NexusBlobStoreSoftQuotaStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusBlobStoreStatus.
+func (in *NexusBlobStoreStatus) DeepCopy() *NexusBlobStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusBlobStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusCleanupPolicy) DeepCopyInto(out *NexusCleanupPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusCleanupPolicy.
+func (in *NexusCleanupPolicy) DeepCopy() *NexusCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusCondition) DeepCopyInto(out *NexusCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusCondition.
+func (in *NexusCondition) DeepCopy() *NexusCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusContentSelector) DeepCopyInto(out *NexusContentSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusContentSelector.
+func (in *NexusContentSelector) DeepCopy() *NexusContentSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusContentSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusJvmOptions) DeepCopyInto(out *NexusJvmOptions) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusJvmOptions.
+func (in *NexusJvmOptions) DeepCopy() *NexusJvmOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusJvmOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusLdap) DeepCopyInto(out *NexusLdap) {
+	*out = *in
+	if in.BindPasswordSecretRef != nil {
+		in, out := &in.BindPasswordSecretRef, &out.BindPasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupMapping != nil {
+		in, out := &in.GroupMapping, &out.GroupMapping
+		*out = new(NexusLdapGroupMapping)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusLdap.
+func (in *NexusLdap) DeepCopy() *NexusLdap {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusLdap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusLdapGroupMapping) DeepCopyInto(out *NexusLdapGroupMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusLdapGroupMapping.
+func (in *NexusLdapGroupMapping) DeepCopy() *NexusLdapGroupMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusLdapGroupMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusLdapUserMapping) DeepCopyInto(out *NexusLdapUserMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusLdapUserMapping.
+func (in *NexusLdapUserMapping) DeepCopy() *NexusLdapUserMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusLdapUserMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NexusList) DeepCopyInto(out *NexusList) {
 	*out = *in
@@ -102,10 +380,307 @@ func (in *NexusList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusPrivilege) DeepCopyInto(out *NexusPrivilege) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusPrivilege.
+func (in *NexusPrivilege) DeepCopy() *NexusPrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusPrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusProbe) DeepCopyInto(out *NexusProbe) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusProbe.
+func (in *NexusProbe) DeepCopy() *NexusProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepository) DeepCopyInto(out *NexusRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepository.
+func (in *NexusRepository) DeepCopy() *NexusRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NexusRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryApt) DeepCopyInto(out *NexusRepositoryApt) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryApt.
+func (in *NexusRepositoryApt) DeepCopy() *NexusRepositoryApt {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryApt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryDocker) DeepCopyInto(out *NexusRepositoryDocker) {
+	*out = *in
+	if in.ForceBasicAuth != nil {
+		in, out := &in.ForceBasicAuth, &out.ForceBasicAuth
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryDocker.
+func (in *NexusRepositoryDocker) DeepCopy() *NexusRepositoryDocker {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryDocker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryGroup) DeepCopyInto(out *NexusRepositoryGroup) {
+	*out = *in
+	if in.MemberNames != nil {
+		in, out := &in.MemberNames, &out.MemberNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryGroup.
+func (in *NexusRepositoryGroup) DeepCopy() *NexusRepositoryGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryList) DeepCopyInto(out *NexusRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NexusRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryList.
+func (in *NexusRepositoryList) DeepCopy() *NexusRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NexusRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryProxy) DeepCopyInto(out *NexusRepositoryProxy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryProxy.
+func (in *NexusRepositoryProxy) DeepCopy() *NexusRepositoryProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryRaw) DeepCopyInto(out *NexusRepositoryRaw) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryRaw.
+func (in *NexusRepositoryRaw) DeepCopy() *NexusRepositoryRaw {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryRaw)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositorySpec) DeepCopyInto(out *NexusRepositorySpec) {
+	*out = *in
+	if in.StrictContentValidation != nil {
+		in, out := &in.StrictContentValidation, &out.StrictContentValidation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(NexusRepositoryProxy)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(NexusRepositoryGroup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Docker != nil {
+		in, out := &in.Docker, &out.Docker
+		*out = new(NexusRepositoryDocker)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Raw != nil {
+		in, out := &in.Raw, &out.Raw
+		*out = new(NexusRepositoryRaw)
+		**out = **in
+	}
+	if in.Apt != nil {
+		in, out := &in.Apt, &out.Apt
+		*out = new(NexusRepositoryApt)
+		**out = **in
+	}
+	if in.Yum != nil {
+		in, out := &in.Yum, &out.Yum
+		*out = new(NexusRepositoryYum)
+		**out = **in
+	}
+	if in.CleanupPolicies != nil {
+		in, out := &in.CleanupPolicies, &out.CleanupPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositorySpec.
+func (in *NexusRepositorySpec) DeepCopy() *NexusRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryStatus) DeepCopyInto(out *NexusRepositoryStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryStatus.
+func (in *NexusRepositoryStatus) DeepCopy() *NexusRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusRepositoryYum) DeepCopyInto(out *NexusRepositoryYum) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusRepositoryYum.
+func (in *NexusRepositoryYum) DeepCopy() *NexusRepositoryYum {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusRepositoryYum)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusS3BlobStore) DeepCopyInto(out *NexusS3BlobStore) {
+	*out = *in
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusS3BlobStore.
+func (in *NexusS3BlobStore) DeepCopy() *NexusS3BlobStore {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusS3BlobStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NexusSpec) DeepCopyInto(out *NexusSpec) {
 	*out = *in
-	out.KeycloakSpec = in.KeycloakSpec
+	in.KeycloakSpec.DeepCopyInto(&out.KeycloakSpec)
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]v1.LocalObjectReference, len(*in))
@@ -124,6 +699,117 @@ func (in *NexusSpec) DeepCopyInto(out *NexusSpec) {
 		}
 	}
 	out.EdpSpec = in.EdpSpec
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(NexusProbe)
+		**out = **in
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(NexusProbe)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JvmOptions != nil {
+		in, out := &in.JvmOptions, &out.JvmOptions
+		*out = new(NexusJvmOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AuthProxy != nil {
+		in, out := &in.AuthProxy, &out.AuthProxy
+		*out = new(NexusAuthProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ldap != nil {
+		in, out := &in.Ldap, &out.Ldap
+		*out = new(NexusLdap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Realms != nil {
+		in, out := &in.Realms, &out.Realms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnonymousAccess != nil {
+		in, out := &in.AnonymousAccess, &out.AnonymousAccess
+		*out = new(NexusAnonymousAccess)
+		**out = **in
+	}
+	if in.BlobStores != nil {
+		in, out := &in.BlobStores, &out.BlobStores
+		*out = make([]NexusBlobStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CleanupPolicies != nil {
+		in, out := &in.CleanupPolicies, &out.CleanupPolicies
+		*out = make([]NexusCleanupPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]NexusTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContentSelectors != nil {
+		in, out := &in.ContentSelectors, &out.ContentSelectors
+		*out = make([]NexusContentSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]NexusPrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -140,6 +826,30 @@ func (in *NexusSpec) DeepCopy() *NexusSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NexusStatus) DeepCopyInto(out *NexusStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]NexusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlobStores != nil {
+		in, out := &in.BlobStores, &out.BlobStores
+		*out = make([]NexusBlobStoreStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]NexusTaskStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.TaskRun != nil {
+		in, out := &in.TaskRun, &out.TaskRun
+		*out = new(NexusTaskRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -153,6 +863,66 @@ func (in *NexusStatus) DeepCopy() *NexusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusTask) DeepCopyInto(out *NexusTask) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusTask.
+func (in *NexusTask) DeepCopy() *NexusTask {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusTaskRunStatus) DeepCopyInto(out *NexusTaskRunStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusTaskRunStatus.
+func (in *NexusTaskRunStatus) DeepCopy() *NexusTaskRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusTaskRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NexusTaskStatus) DeepCopyInto(out *NexusTaskStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NexusTaskStatus.
+func (in *NexusTaskStatus) DeepCopy() *NexusTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NexusTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NexusUsers) DeepCopyInto(out *NexusUsers) {
 	*out = *in
@@ -13,9 +13,12 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"nexus-operator/pkg/apis/edp/v1alpha1.Nexus":       schema_pkg_apis_edp_v1alpha1_Nexus(ref),
-		"nexus-operator/pkg/apis/edp/v1alpha1.NexusSpec":   schema_pkg_apis_edp_v1alpha1_NexusSpec(ref),
-		"nexus-operator/pkg/apis/edp/v1alpha1.NexusStatus": schema_pkg_apis_edp_v1alpha1_NexusStatus(ref),
+		"nexus-operator/pkg/apis/edp/v1alpha1.Nexus":                 schema_pkg_apis_edp_v1alpha1_Nexus(ref),
+		"nexus-operator/pkg/apis/edp/v1alpha1.NexusRepository":       schema_pkg_apis_edp_v1alpha1_NexusRepository(ref),
+		"nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositorySpec":   schema_pkg_apis_edp_v1alpha1_NexusRepositorySpec(ref),
+		"nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryStatus": schema_pkg_apis_edp_v1alpha1_NexusRepositoryStatus(ref),
+		"nexus-operator/pkg/apis/edp/v1alpha1.NexusSpec":             schema_pkg_apis_edp_v1alpha1_NexusSpec(ref),
+		"nexus-operator/pkg/apis/edp/v1alpha1.NexusStatus":           schema_pkg_apis_edp_v1alpha1_NexusStatus(ref),
 	}
 }
 
@@ -63,6 +66,209 @@ func schema_pkg_apis_edp_v1alpha1_Nexus(ref common.ReferenceCallback) common.Ope
 	}
 }
 
+func schema_pkg_apis_edp_v1alpha1_NexusRepository(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NexusRepository is the Schema for the nexusrepositories API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositorySpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositorySpec", "nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryStatus"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_NexusRepositorySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NexusRepositorySpec defines the desired state of NexusRepository",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ownerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerName is the name of the Nexus CR in the same namespace that hosts the repository",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the repository in Nexus, defaults to the name of the CR",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format of the repository: maven, npm, nuget, docker, pypi, helm, raw, go, apt or yum",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the repository: hosted, proxy or group",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"blobStore": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"strictContentValidation": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"writePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"versionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"layoutPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"proxy": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryProxy"),
+						},
+					},
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryGroup"),
+						},
+					},
+					"docker": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Docker configures registry of docker repository",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryDocker"),
+						},
+					},
+					"raw": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Raw configures how content of raw repository is served",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryRaw"),
+						},
+					},
+					"apt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Apt configures distribution and signing of apt repository",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryApt"),
+						},
+					},
+					"yum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Yum configures metadata and signing of yum repository",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryYum"),
+						},
+					},
+					"cleanupPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CleanupPolicies are names of cleanup policies from Nexus spec which are applied to hosted or proxy repository",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"ownerName", "format", "type"},
+			},
+		},
+		Dependencies: []string{
+			"nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryApt", "nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryDocker", "nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryGroup", "nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryProxy", "nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryRaw", "nexus-operator/pkg/apis/edp/v1alpha1.NexusRepositoryYum"},
+	}
+}
+
+func schema_pkg_apis_edp_v1alpha1_NexusRepositoryStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NexusRepositoryStatus defines the observed state of NexusRepository",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"available": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"lastTimeUpdated": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "date-time",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"detailedMessage": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_edp_v1alpha1_NexusSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -77,8 +283,9 @@ func schema_pkg_apis_edp_v1alpha1_NexusSpec(ref common.ReferenceCallback) common
 					},
 					"version": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Version of Nexus image. Change of version upgrades Nexus, data volume is archived to <name>-backup volume before the new version is rolled out. S3 blob stores are not backed up, they have to be backed up separately",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"volumes": {
@@ -95,7 +302,8 @@ func schema_pkg_apis_edp_v1alpha1_NexusSpec(ref common.ReferenceCallback) common
 					},
 					"users": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Users are created with random initial password, it is saved to Secret <name>-user-<username>",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -110,12 +318,235 @@ func schema_pkg_apis_edp_v1alpha1_NexusSpec(ref common.ReferenceCallback) common
 							Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.EdpSpec"),
 						},
 					},
+					"volumeRetentionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeRetentionPolicy defines whether volumes are deleted (Delete, default) or kept (Retain) with Nexus CR",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources of Nexus container, memory request of 500Mi is used if not set",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"livenessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LivenessProbe overrides parameters of default liveness probe of Nexus container",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusProbe"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessProbe overrides parameters of default readiness probe of Nexus container",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusProbe"),
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env is a list of additional environment variables of Nexus container",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"jvmOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JvmOptions are passed to Nexus through INSTALL4J_ADD_VM_PARAMS, defaults of the image are used if not set",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusJvmOptions"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector of Nexus pod",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations of Nexus pod, they are applied to backup Job as well since it runs on the node of Nexus pod",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Affinity of Nexus pod",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName of Nexus pod",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodAnnotations are added to Nexus pod",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"podLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodLabels are added to Nexus pod, app label can not be overridden",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"authProxy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AuthProxy configures authentication proxy which is placed in front of Nexus if Keycloak integration is enabled",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusAuthProxy"),
+						},
+					},
+					"ldap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ldap configures LDAP server which is used by LdapRealm to authenticate users, LdapRealm has to be listed in realms if they are set",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusLdap"),
+						},
+					},
+					"realms": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Realms are activated in the given order and the rest of realms are deactivated. NexusAuthenticatingRealm is always active since it is used by operator, rutauth-realm has to be listed if Keycloak integration is enabled. Realms require realms REST API of Nexus",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"anonymousAccess": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AnonymousAccess configures access of unauthenticated users, current settings are kept if it is not set",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusAnonymousAccess"),
+						},
+					},
+					"blobStores": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BlobStores are created in addition to the ones from blobs ConfigMap, existing blob stores are updated",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusBlobStore"),
+									},
+								},
+							},
+						},
+					},
+					"cleanupPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CleanupPolicies are created or updated in Nexus, policies which have been removed from spec are deleted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusCleanupPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"cleanupTaskCron": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CleanupTaskCron is a schedule of Cleanup service task which applies cleanup policies, 0 0 1 * * ? by default",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tasks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tasks are scheduled in addition to the ones from default-tasks ConfigMap, task from spec overrides the one from ConfigMap with the same name. Tasks which have been removed from both are deleted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusTask"),
+									},
+								},
+							},
+						},
+					},
+					"contentSelectors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContentSelectors are created or updated in Nexus, selectors which have been removed from spec are deleted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusContentSelector"),
+									},
+								},
+							},
+						},
+					},
+					"privileges": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Privileges are created or updated in Nexus and can be granted by roles from default-roles ConfigMap. Privileges which have been removed from spec are deleted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusPrivilege"),
+									},
+								},
+							},
+						},
+					},
+					"userRemovalPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UserRemovalPolicy defines whether users which have been removed from spec are disabled (Disable, default) or deleted (Delete). Disable is the default since deleted user can't be restored: password changed by the user and roles granted outside of spec are lost, while disabled user is enabled again when it is returned to spec",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"version", "edpSpec"},
 			},
 		},
 		Dependencies: []string{
-			"nexus-operator/pkg/apis/edp/v1alpha1.EdpSpec", "nexus-operator/pkg/apis/edp/v1alpha1.KeycloakSpec", "nexus-operator/pkg/apis/edp/v1alpha1.NexusUsers", "nexus-operator/pkg/apis/edp/v1alpha1.NexusVolumes"},
+			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration", "nexus-operator/pkg/apis/edp/v1alpha1.EdpSpec", "nexus-operator/pkg/apis/edp/v1alpha1.KeycloakSpec", "nexus-operator/pkg/apis/edp/v1alpha1.NexusAnonymousAccess", "nexus-operator/pkg/apis/edp/v1alpha1.NexusAuthProxy", "nexus-operator/pkg/apis/edp/v1alpha1.NexusBlobStore", "nexus-operator/pkg/apis/edp/v1alpha1.NexusCleanupPolicy", "nexus-operator/pkg/apis/edp/v1alpha1.NexusContentSelector", "nexus-operator/pkg/apis/edp/v1alpha1.NexusJvmOptions", "nexus-operator/pkg/apis/edp/v1alpha1.NexusLdap", "nexus-operator/pkg/apis/edp/v1alpha1.NexusPrivilege", "nexus-operator/pkg/apis/edp/v1alpha1.NexusProbe", "nexus-operator/pkg/apis/edp/v1alpha1.NexusTask", "nexus-operator/pkg/apis/edp/v1alpha1.NexusUsers", "nexus-operator/pkg/apis/edp/v1alpha1.NexusVolumes"},
 	}
 }
 
@@ -144,8 +575,75 @@ func schema_pkg_apis_edp_v1alpha1_NexusStatus(ref common.ReferenceCallback) comm
 							Format: "",
 						},
 					},
+					"currentVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentVersion is the version of deployed Nexus, differs from spec version until upgrade is done",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rejectedVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RejectedVersion is the spec version upgrade to which has failed validation, upgrade is not retried until spec version changes and the current version stays deployed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of Nexus CR which has been reconciled successfully",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusCondition"),
+									},
+								},
+							},
+						},
+					},
+					"blobStores": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BlobStores is the usage of blob stores of Nexus, it is refreshed periodically",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusBlobStoreStatus"),
+									},
+								},
+							},
+						},
+					},
+					"tasks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tasks is the state of scheduled tasks created by operator, it is refreshed periodically",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusTaskStatus"),
+									},
+								},
+							},
+						},
+					},
+					"taskRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TaskRun is the state of the last task run requested with run-task annotation",
+							Ref:         ref("nexus-operator/pkg/apis/edp/v1alpha1.NexusTaskRunStatus"),
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"nexus-operator/pkg/apis/edp/v1alpha1.NexusBlobStoreStatus", "nexus-operator/pkg/apis/edp/v1alpha1.NexusCondition", "nexus-operator/pkg/apis/edp/v1alpha1.NexusTaskRunStatus", "nexus-operator/pkg/apis/edp/v1alpha1.NexusTaskStatus"},
 	}
 }
@@ -0,0 +1,133 @@
+package nexus
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// BlobStoreInfo is the representation of blob store returned by blob stores list
+type BlobStoreInfo struct {
+	Name                  string              `json:"name"`
+	Type                  string              `json:"type"`
+	BlobCount             int64               `json:"blobCount"`
+	TotalSizeInBytes      int64               `json:"totalSizeInBytes"`
+	AvailableSpaceInBytes int64               `json:"availableSpaceInBytes"`
+	SoftQuota             *BlobStoreSoftQuota `json:"softQuota,omitempty"`
+}
+
+// BlobStoreSoftQuota is soft quota of blob store, limit is set in megabytes
+type BlobStoreSoftQuota struct {
+	Type  string `json:"type"`
+	Limit int64  `json:"limit"`
+}
+
+// BlobStoreQuotaStatus is the result of soft quota check of blob store
+type BlobStoreQuotaStatus struct {
+	IsViolation   bool   `json:"isViolation"`
+	Message       string `json:"message"`
+	BlobStoreName string `json:"blobStoreName"`
+}
+
+// FileBlobStore is the request model of file blob stores API
+type FileBlobStore struct {
+	Name      string              `json:"name"`
+	Path      string              `json:"path"`
+	SoftQuota *BlobStoreSoftQuota `json:"softQuota,omitempty"`
+}
+
+// S3BlobStore is the request model of S3 blob stores API
+type S3BlobStore struct {
+	Name                string                         `json:"name"`
+	SoftQuota           *BlobStoreSoftQuota            `json:"softQuota,omitempty"`
+	BucketConfiguration S3BlobStoreBucketConfiguration `json:"bucketConfiguration"`
+}
+
+type S3BlobStoreBucketConfiguration struct {
+	Bucket                   S3BlobStoreBucket                    `json:"bucket"`
+	BucketSecurity           *S3BlobStoreBucketSecurity           `json:"bucketSecurity,omitempty"`
+	AdvancedBucketConnection *S3BlobStoreAdvancedBucketConnection `json:"advancedBucketConnection,omitempty"`
+}
+
+type S3BlobStoreBucket struct {
+	Region     string `json:"region"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix,omitempty"`
+	Expiration int32  `json:"expiration"`
+}
+
+type S3BlobStoreBucketSecurity struct {
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	SessionToken    string `json:"sessionToken,omitempty"`
+}
+
+type S3BlobStoreAdvancedBucketConnection struct {
+	Endpoint       string `json:"endpoint,omitempty"`
+	ForcePathStyle bool   `json:"forcePathStyle"`
+}
+
+// GetBlobStores returns all blob stores with their usage
+func (nc NexusClient) GetBlobStores() ([]BlobStoreInfo, error) {
+	var out []BlobStoreInfo
+	if err := nc.doRequest(http.MethodGet, "/blobstores", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetBlobStore returns blob store or nil if it doesn't exist
+func (nc NexusClient) GetBlobStore(name string) (*BlobStoreInfo, error) {
+	blobStores, err := nc.GetBlobStores()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range blobStores {
+		if b.Name == name {
+			return &b, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetBlobStoreQuotaStatus checks whether soft quota of blob store is violated
+func (nc NexusClient) GetBlobStoreQuotaStatus(name string) (*BlobStoreQuotaStatus, error) {
+	var out BlobStoreQuotaStatus
+	if err := nc.doRequest(http.MethodGet, fmt.Sprintf("/blobstores/%v/quota-status", pathEscape(name)), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateFileBlobStore creates file blob store
+func (nc NexusClient) CreateFileBlobStore(blobStore FileBlobStore) error {
+	return nc.doRequest(http.MethodPost, "/blobstores/file", blobStore, nil)
+}
+
+// UpdateFileBlobStore updates existing file blob store
+func (nc NexusClient) UpdateFileBlobStore(blobStore FileBlobStore) error {
+	return nc.doRequest(http.MethodPut, fmt.Sprintf("/blobstores/file/%v", pathEscape(blobStore.Name)), blobStore, nil)
+}
+
+// CreateS3BlobStore creates S3 blob store
+func (nc NexusClient) CreateS3BlobStore(blobStore S3BlobStore) error {
+	return nc.doRequest(http.MethodPost, "/blobstores/s3", blobStore, nil)
+}
+
+// GetS3BlobStore returns configuration of S3 blob store, secret access key is not returned by REST API
+func (nc NexusClient) GetS3BlobStore(name string) (*S3BlobStore, error) {
+	var out S3BlobStore
+	if err := nc.doRequest(http.MethodGet, fmt.Sprintf("/blobstores/s3/%v", pathEscape(name)), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateS3BlobStore updates existing S3 blob store. Credentials have to be passed on every update
+func (nc NexusClient) UpdateS3BlobStore(blobStore S3BlobStore) error {
+	return nc.doRequest(http.MethodPut, fmt.Sprintf("/blobstores/s3/%v", pathEscape(blobStore.Name)), blobStore, nil)
+}
+
+// DeleteBlobStore deletes blob store by name
+func (nc NexusClient) DeleteBlobStore(name string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/blobstores/%v", pathEscape(name)), nil, nil)
+}
@@ -0,0 +1,48 @@
+package nexus
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Capability is the model of capabilities API
+type Capability struct {
+	Id         string            `json:"id,omitempty"`
+	Type       string            `json:"type"`
+	Notes      string            `json:"notes,omitempty"`
+	Enabled    bool              `json:"enabled"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// GetCapabilities returns all configured capabilities. Nexus versions without capabilities API respond with 404
+func (nc NexusClient) GetCapabilities() ([]Capability, error) {
+	var out []Capability
+	if err := nc.doRequest(http.MethodGet, "/capabilities", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCapabilityByType returns the first capability of the given type or nil if it doesn't exist
+func (nc NexusClient) GetCapabilityByType(capabilityType string) (*Capability, error) {
+	capabilities, err := nc.GetCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range capabilities {
+		if c.Type == capabilityType {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateCapability creates capability
+func (nc NexusClient) CreateCapability(capability Capability) error {
+	return nc.doRequest(http.MethodPost, "/capabilities", capability, nil)
+}
+
+// UpdateCapability updates existing capability by id
+func (nc NexusClient) UpdateCapability(capability Capability) error {
+	return nc.doRequest(http.MethodPut, fmt.Sprintf("/capabilities/%v", pathEscape(capability.Id)), capability, nil)
+}
@@ -0,0 +1,55 @@
+package nexus
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// CleanupPolicy is the model of cleanup policies API, criteria are set in days
+type CleanupPolicy struct {
+	Name                    string `json:"name"`
+	Notes                   string `json:"notes,omitempty"`
+	Format                  string `json:"format"`
+	CriteriaLastBlobUpdated *int32 `json:"criteriaLastBlobUpdated,omitempty"`
+	CriteriaLastDownloaded  *int32 `json:"criteriaLastDownloaded,omitempty"`
+	CriteriaReleaseType     string `json:"criteriaReleaseType,omitempty"`
+	CriteriaAssetRegex      string `json:"criteriaAssetRegex,omitempty"`
+}
+
+// GetCleanupPolicies returns all cleanup policies
+func (nc NexusClient) GetCleanupPolicies() ([]CleanupPolicy, error) {
+	var out []CleanupPolicy
+	if err := nc.doRequest(http.MethodGet, "/cleanup-policies", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCleanupPolicy returns cleanup policy by name or nil if it doesn't exist
+func (nc NexusClient) GetCleanupPolicy(name string) (*CleanupPolicy, error) {
+	policies, err := nc.GetCleanupPolicies()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		if p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateCleanupPolicy creates cleanup policy
+func (nc NexusClient) CreateCleanupPolicy(policy CleanupPolicy) error {
+	return nc.doRequest(http.MethodPost, "/cleanup-policies", policy, nil)
+}
+
+// UpdateCleanupPolicy updates existing cleanup policy
+func (nc NexusClient) UpdateCleanupPolicy(policy CleanupPolicy) error {
+	return nc.doRequest(http.MethodPut, fmt.Sprintf("/cleanup-policies/%v", pathEscape(policy.Name)), policy, nil)
+}
+
+// DeleteCleanupPolicy deletes cleanup policy by name
+func (nc NexusClient) DeleteCleanupPolicy(name string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/cleanup-policies/%v", pathEscape(name)), nil, nil)
+}
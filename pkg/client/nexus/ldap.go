@@ -0,0 +1,74 @@
+package nexus
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// LdapServer is the model of security LDAP API
+type LdapServer struct {
+	Id                          string `json:"id,omitempty"`
+	Name                        string `json:"name"`
+	Protocol                    string `json:"protocol"`
+	UseTrustStore               bool   `json:"useTrustStore"`
+	Host                        string `json:"host"`
+	Port                        int32  `json:"port"`
+	SearchBase                  string `json:"searchBase"`
+	AuthScheme                  string `json:"authScheme"`
+	AuthRealm                   string `json:"authRealm,omitempty"`
+	AuthUsername                string `json:"authUsername,omitempty"`
+	AuthPassword                string `json:"authPassword,omitempty"`
+	ConnectionTimeoutSeconds    int32  `json:"connectionTimeoutSeconds"`
+	ConnectionRetryDelaySeconds int32  `json:"connectionRetryDelaySeconds"`
+	MaxIncidentsCount           int32  `json:"maxIncidentsCount"`
+	UserBaseDn                  string `json:"userBaseDn,omitempty"`
+	UserSubtree                 bool   `json:"userSubtree"`
+	UserObjectClass             string `json:"userObjectClass"`
+	UserLdapFilter              string `json:"userLdapFilter,omitempty"`
+	UserIdAttribute             string `json:"userIdAttribute"`
+	UserRealNameAttribute       string `json:"userRealNameAttribute"`
+	UserEmailAddressAttribute   string `json:"userEmailAddressAttribute"`
+	UserPasswordAttribute       string `json:"userPasswordAttribute,omitempty"`
+	LdapGroupsAsRoles           bool   `json:"ldapGroupsAsRoles"`
+	GroupType                   string `json:"groupType,omitempty"`
+	GroupBaseDn                 string `json:"groupBaseDn,omitempty"`
+	GroupSubtree                bool   `json:"groupSubtree"`
+	GroupObjectClass            string `json:"groupObjectClass,omitempty"`
+	GroupIdAttribute            string `json:"groupIdAttribute,omitempty"`
+	GroupMemberAttribute        string `json:"groupMemberAttribute,omitempty"`
+	GroupMemberFormat           string `json:"groupMemberFormat,omitempty"`
+	UserMemberOfAttribute       string `json:"userMemberOfAttribute,omitempty"`
+}
+
+// GetLdapServers returns all LDAP servers in the order of their priority
+func (nc NexusClient) GetLdapServers() ([]LdapServer, error) {
+	var out []LdapServer
+	if err := nc.doRequest(http.MethodGet, "/security/ldap", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetLdapServer returns LDAP server by name or nil if it doesn't exist
+func (nc NexusClient) GetLdapServer(name string) (*LdapServer, error) {
+	servers, err := nc.GetLdapServers()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range servers {
+		if s.Name == name {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateLdapServer creates LDAP server
+func (nc NexusClient) CreateLdapServer(server LdapServer) error {
+	return nc.doRequest(http.MethodPost, "/security/ldap", server, nil)
+}
+
+// UpdateLdapServer updates existing LDAP server. Bind password has to be passed on every update
+func (nc NexusClient) UpdateLdapServer(name string, server LdapServer) error {
+	return nc.doRequest(http.MethodPut, fmt.Sprintf("/security/ldap/%v", pathEscape(name)), server, nil)
+}
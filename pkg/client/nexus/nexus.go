@@ -8,18 +8,21 @@ import (
 	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
 	"github.com/pkg/errors"
 	"gopkg.in/resty.v1"
+	"net/http"
 	"strings"
 )
 
 type NexusClient struct {
 	instance *v1alpha1.Nexus
 	resty    resty.Client
+	apis     *apiSupport
 }
 
 // InitNewRestClient performs initialization of Nexus connection
 func (nc *NexusClient) InitNewRestClient(instance *v1alpha1.Nexus, url string, user string, password string) error {
-	nc.resty = *resty.SetHostURL(url).SetBasicAuth(user, password)
+	nc.resty = *resty.New().SetHostURL(url).SetBasicAuth(user, password)
 	nc.instance = instance
+	nc.apis = &apiSupport{supported: map[string]bool{}}
 	return nil
 }
 
@@ -57,19 +60,65 @@ func (nc NexusClient) CheckScriptExist(scriptName string) (bool, error) {
 	return false, nil
 }
 
+// Script is the model of uploaded script
+type Script struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// GetScripts returns all scripts uploaded to Nexus
+func (nc NexusClient) GetScripts() ([]Script, error) {
+	var out []Script
+	if err := nc.doRequest(http.MethodGet, "/script", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func scriptBody(scriptName string, scriptType string, scriptContent string) string {
+	formattedContent := nexusClientHelper.FormateNexusScript(scriptContent)
+	return `{"name":"` + scriptName + `", "type":"` + scriptType + `", "content": "` + formattedContent + `"}`
+}
+
 // UploadScript uploads script to Nexus
 func (nc NexusClient) UploadScript(scriptName string, scriptType string, scriptContent string) error {
-	formattedContent := nexusClientHelper.FormateNexusScript(scriptContent)
 	resp, err := nc.resty.R().
-		SetBody(`{"name":"` + scriptName + `", "type":"` + scriptType + `", "content": "` + formattedContent + `"}`).
+		SetBody(scriptBody(scriptName, scriptType, scriptContent)).
 		SetHeaders(map[string]string{"accept": "application/json", "Content-type": "application/json"}).
 		Post("/script")
-	if err != nil || resp.IsError() {
-		return helper.LogErrorAndReturn(errors.New(fmt.Sprintf("Uploading script %v failed. Err - %v. Response - %s", scriptName, err, resp.Status())))
+	if err != nil {
+		return helper.LogErrorAndReturn(errors.New(fmt.Sprintf("Uploading script %v failed. Err - %v", scriptName, err)))
+	}
+	if resp.IsError() {
+		return errors.Wrapf(NexusApiError{Method: "POST", Path: "/script", StatusCode: resp.StatusCode(), Message: string(resp.Body())},
+			"Uploading script %v failed", scriptName)
+	}
+	return nil
+}
+
+// UpdateScript replaces content of already uploaded script
+func (nc NexusClient) UpdateScript(scriptName string, scriptType string, scriptContent string) error {
+	path := fmt.Sprintf("/script/%v", scriptName)
+	resp, err := nc.resty.R().
+		SetBody(scriptBody(scriptName, scriptType, scriptContent)).
+		SetHeaders(map[string]string{"accept": "application/json", "Content-type": "application/json"}).
+		Put(path)
+	if err != nil {
+		return errors.Wrapf(err, "Updating script %v failed", scriptName)
+	}
+	if resp.IsError() {
+		return errors.Wrapf(NexusApiError{Method: "PUT", Path: path, StatusCode: resp.StatusCode(), Message: string(resp.Body())},
+			"Updating script %v failed", scriptName)
 	}
 	return nil
 }
 
+// DeleteScript deletes uploaded script
+func (nc NexusClient) DeleteScript(scriptName string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/script/%v", scriptName), nil, nil)
+}
+
 // AreDefaultScriptsDeclared checks if default scripts are already declared in Nexus
 func (nc NexusClient) AreDefaultScriptsDeclared(listOfScripts map[string]string) (bool, error) {
 	defaultScriptsAreDeclared := true
@@ -87,44 +136,6 @@ func (nc NexusClient) AreDefaultScriptsDeclared(listOfScripts map[string]string)
 	return defaultScriptsAreDeclared, nil
 }
 
-// DeclareDefaultScripts declares default scripts in Nexus
-func (nc NexusClient) DeclareDefaultScripts(listOfScripts map[string]string) error {
-	for scriptFullName, scriptContent := range listOfScripts {
-		scriptName := strings.Split(scriptFullName, ".")[0]
-		scriptExtension := strings.Split(scriptFullName, ".")[1]
-		scriptExist, err := nc.CheckScriptExist(scriptName)
-		if err != nil {
-			return err
-		}
-		if !scriptExist {
-			err := nc.UploadScript(scriptName, scriptExtension, scriptContent)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-// CheckScriptExist checks if task is already uploaded
-func (nc NexusClient) CheckTaskExist(taskName string) (bool, error) {
-	resp, err := nc.resty.R().
-		SetHeader("accept", "application/json").
-		Get("/tasks")
-	if err != nil || resp.IsError() {
-		return false, helper.LogErrorAndReturn(errors.New(fmt.Sprintf("Checking if task %v exist failed. Err - %v. Response - %s", taskName, err, resp.Status())))
-	}
-
-	var tasksList map[string][]map[string]interface{}
-	err = json.Unmarshal(resp.Body(), &tasksList)
-	for _, task := range tasksList["items"] {
-		if task["name"] == taskName {
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
 // RunScript runs script in Nexus
 func (nc NexusClient) RunScript(scriptName string, parameters map[string]interface{}) ([]byte, error) {
 	body, err := json.Marshal(parameters)
@@ -135,8 +146,12 @@ func (nc NexusClient) RunScript(scriptName string, parameters map[string]interfa
 		SetBody(body).
 		SetHeader("Content-type", "text/plain").
 		Post(fmt.Sprintf("/script/%v/run", scriptName))
-	if err != nil || resp.IsError() {
-		return nil, errors.Wrapf(err, fmt.Sprintf("Running script %v failed. Response - %s", scriptName, resp.Status()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Running script %v failed", scriptName)
+	}
+	if resp.IsError() {
+		return nil, errors.Wrapf(NexusApiError{Method: "POST", Path: fmt.Sprintf("/script/%v/run", scriptName), StatusCode: resp.StatusCode(), Message: string(resp.Body())},
+			"Running script %v failed", scriptName)
 	}
 	return resp.Body(), nil
 }
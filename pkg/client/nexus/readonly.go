@@ -0,0 +1,50 @@
+package nexus
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ReadOnlyState is the model of database read-only state
+type ReadOnlyState struct {
+	SystemInitiated bool   `json:"systemInitiated"`
+	SummaryReason   string `json:"summaryReason"`
+	Frozen          bool   `json:"frozen"`
+}
+
+// GetReadOnlyState returns read-only state of Nexus
+func (nc NexusClient) GetReadOnlyState() (*ReadOnlyState, error) {
+	var out ReadOnlyState
+	if err := nc.doRequest(http.MethodGet, "/read-only", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FreezeReadOnly puts Nexus into read-only mode
+func (nc NexusClient) FreezeReadOnly() error {
+	return nc.doRequest(http.MethodPost, "/read-only/freeze", nil, nil)
+}
+
+// ReleaseReadOnly restores write mode of Nexus
+func (nc NexusClient) ReleaseReadOnly() error {
+	return nc.doRequest(http.MethodPost, "/read-only/release", nil, nil)
+}
+
+// GetServerVersion returns Nexus version reported in Server header, e.g. 3.29.0-02 for "Nexus/3.29.0-02 (OSS)".
+// Empty string is returned if the header is not present
+func (nc NexusClient) GetServerVersion() (string, error) {
+	resp, err := nc.resty.R().Get("/status")
+	if err != nil {
+		return "", err
+	}
+	if resp.IsError() {
+		return "", NexusApiError{Method: http.MethodGet, Path: "/status", StatusCode: resp.StatusCode(), Message: string(resp.Body())}
+	}
+
+	server := strings.Fields(resp.Header().Get("Server"))
+	if len(server) == 0 || !strings.HasPrefix(server[0], "Nexus/") {
+		return "", nil
+	}
+	return strings.TrimPrefix(server[0], "Nexus/"), nil
+}
@@ -0,0 +1,148 @@
+package nexus
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// RepositoryInfo is the short representation of repository returned by repositories list
+type RepositoryInfo struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Type   string `json:"type"`
+	Url    string `json:"url"`
+}
+
+// Repository is the request model of repositories API. Format and Type define the endpoint
+type Repository struct {
+	Format        string                   `json:"-"`
+	Type          string                   `json:"-"`
+	Name          string                   `json:"name"`
+	Online        bool                     `json:"online"`
+	Storage       *RepositoryStorage       `json:"storage,omitempty"`
+	Cleanup       *RepositoryCleanup       `json:"cleanup,omitempty"`
+	Proxy         *RepositoryProxy         `json:"proxy,omitempty"`
+	NegativeCache *RepositoryNegativeCache `json:"negativeCache,omitempty"`
+	HttpClient    *RepositoryHttpClient    `json:"httpClient,omitempty"`
+	Group         *RepositoryGroup         `json:"group,omitempty"`
+	Maven         *RepositoryMaven         `json:"maven,omitempty"`
+	NugetProxy    *RepositoryNugetProxy    `json:"nugetProxy,omitempty"`
+	Docker        *RepositoryDocker        `json:"docker,omitempty"`
+	DockerProxy   *RepositoryDockerProxy   `json:"dockerProxy,omitempty"`
+	Raw           *RepositoryRaw           `json:"raw,omitempty"`
+	Apt           *RepositoryApt           `json:"apt,omitempty"`
+	AptSigning    *RepositorySigning       `json:"aptSigning,omitempty"`
+	Yum           *RepositoryYum           `json:"yum,omitempty"`
+	YumSigning    *RepositorySigning       `json:"yumSigning,omitempty"`
+}
+
+type RepositoryStorage struct {
+	BlobStoreName               string `json:"blobStoreName"`
+	StrictContentTypeValidation bool   `json:"strictContentTypeValidation"`
+	WritePolicy                 string `json:"writePolicy,omitempty"`
+}
+
+type RepositoryCleanup struct {
+	PolicyNames []string `json:"policyNames"`
+}
+
+type RepositoryProxy struct {
+	RemoteUrl      string `json:"remoteUrl"`
+	ContentMaxAge  int    `json:"contentMaxAge"`
+	MetadataMaxAge int    `json:"metadataMaxAge"`
+}
+
+type RepositoryNegativeCache struct {
+	Enabled    bool `json:"enabled"`
+	TimeToLive int  `json:"timeToLive"`
+}
+
+type RepositoryHttpClient struct {
+	Blocked   bool `json:"blocked"`
+	AutoBlock bool `json:"autoBlock"`
+}
+
+type RepositoryGroup struct {
+	MemberNames []string `json:"memberNames"`
+}
+
+type RepositoryMaven struct {
+	VersionPolicy string `json:"versionPolicy"`
+	LayoutPolicy  string `json:"layoutPolicy"`
+}
+
+type RepositoryNugetProxy struct {
+	QueryCacheItemMaxAge int    `json:"queryCacheItemMaxAge"`
+	NugetVersion         string `json:"nugetVersion"`
+}
+
+type RepositoryDocker struct {
+	V1Enabled      bool   `json:"v1Enabled"`
+	ForceBasicAuth bool   `json:"forceBasicAuth"`
+	HttpPort       *int32 `json:"httpPort,omitempty"`
+	HttpsPort      *int32 `json:"httpsPort,omitempty"`
+}
+
+type RepositoryDockerProxy struct {
+	IndexType string `json:"indexType"`
+	IndexUrl  string `json:"indexUrl,omitempty"`
+}
+
+type RepositoryRaw struct {
+	ContentDisposition string `json:"contentDisposition"`
+}
+
+type RepositoryApt struct {
+	Distribution string `json:"distribution"`
+	Flat         *bool  `json:"flat,omitempty"`
+}
+
+type RepositoryYum struct {
+	RepodataDepth int32  `json:"repodataDepth"`
+	DeployPolicy  string `json:"deployPolicy,omitempty"`
+}
+
+// RepositorySigning is GPG keypair which signs metadata of apt and yum repositories
+type RepositorySigning struct {
+	Keypair    string `json:"keypair"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// GetRepositories returns short representation of all repositories in Nexus
+func (nc NexusClient) GetRepositories() ([]RepositoryInfo, error) {
+	var out []RepositoryInfo
+	if err := nc.doRequest(http.MethodGet, "/repositories", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRepository returns short representation of repository or nil if it doesn't exist
+func (nc NexusClient) GetRepository(name string) (*RepositoryInfo, error) {
+	repositories, err := nc.GetRepositories()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range repositories {
+		if r.Name == name {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateRepository creates repository of the given format and type
+func (nc NexusClient) CreateRepository(repository Repository) error {
+	return nc.doRequest(http.MethodPost, fmt.Sprintf("/repositories/%v/%v", repository.Format, repository.Type), repository, nil)
+}
+
+// UpdateRepository updates existing repository of the given format and type
+func (nc NexusClient) UpdateRepository(repository Repository) error {
+	return nc.doRequest(http.MethodPut,
+		fmt.Sprintf("/repositories/%v/%v/%v", repository.Format, repository.Type, pathEscape(repository.Name)), repository, nil)
+}
+
+// DeleteRepository deletes repository by name
+func (nc NexusClient) DeleteRepository(name string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/repositories/%v", pathEscape(name)), nil, nil)
+}
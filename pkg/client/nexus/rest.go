@@ -0,0 +1,144 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Collection endpoints of REST API, IsApiSupported checks availability of the API by them
+const (
+	AnonymousAccessApi  = "/security/anonymous"
+	BlobStoresApi       = "/blobstores"
+	CapabilitiesApi     = "/capabilities"
+	CleanupPoliciesApi  = "/cleanup-policies"
+	ContentSelectorsApi = "/security/content-selectors"
+	LdapApi             = "/security/ldap"
+	PrivilegesApi       = "/security/privileges"
+	RealmsApi           = "/security/realms/available"
+	RepositoriesApi     = "/repositories"
+	RolesApi            = "/security/roles"
+	UsersApi            = "/security/users"
+)
+
+// RepositoriesApiVersion - first Nexus version which manages repositories of all formats through REST API.
+// List of repositories is available in older versions, so availability of the API is checked by version
+const RepositoriesApiVersion = "3.21.0"
+
+// NexusApiError describes unsuccessful response of Nexus REST API
+type NexusApiError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+func (e NexusApiError) Error() string {
+	return fmt.Sprintf("%v %v failed. Response - %v %v", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// IsErrNotFound checks if error is caused by 404 response of Nexus REST API
+func IsErrNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsErrNotSupported checks if error of collection request means that REST API endpoint is not available in running
+// Nexus version. The same response of item request may mean that the item is absent, IsApiSupported tells them apart
+func IsErrNotSupported(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound, http.StatusMethodNotAllowed)
+}
+
+// IsErrScriptingDisabled checks if error is caused by disabled script creation (nexus.scripts.allowCreation=false)
+func IsErrScriptingDisabled(err error) bool {
+	return hasStatusCode(err, http.StatusGone)
+}
+
+func hasStatusCode(err error, codes ...int) bool {
+	apiErr, ok := errors.Cause(err).(NexusApiError)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// apiSupport keeps availability of REST APIs in running Nexus, it is shared by copies of client
+type apiSupport struct {
+	mu        sync.Mutex
+	supported map[string]bool
+}
+
+// IsApiSupported checks if REST API with the given collection endpoint is available in running Nexus version.
+// The collection is listed once per client, repositories API is checked by Nexus version instead
+func (nc NexusClient) IsApiSupported(api string) (bool, error) {
+	if nc.apis == nil {
+		return nc.checkApiSupport(api)
+	}
+	nc.apis.mu.Lock()
+	defer nc.apis.mu.Unlock()
+	if supported, ok := nc.apis.supported[api]; ok {
+		return supported, nil
+	}
+	supported, err := nc.checkApiSupport(api)
+	if err != nil {
+		return false, err
+	}
+	nc.apis.supported[api] = supported
+	return supported, nil
+}
+
+func (nc NexusClient) checkApiSupport(api string) (bool, error) {
+	if api == RepositoriesApi {
+		version, err := nc.GetServerVersion()
+		if err != nil {
+			return false, err
+		}
+		// Server header may be hidden by proxy, the collection is listed then
+		if current, err := ParseVersion(version); err == nil {
+			minimal, _ := ParseVersion(RepositoriesApiVersion)
+			return !current.Less(minimal), nil
+		}
+	}
+	err := nc.doRequest(http.MethodGet, api, nil, nil)
+	if err == nil {
+		return true, nil
+	}
+	if IsErrNotSupported(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// doRequest performs JSON request to Nexus REST API and unmarshals response body into result if it is not nil
+func (nc NexusClient) doRequest(method string, path string, body interface{}, result interface{}) error {
+	req := nc.resty.R().SetHeader("accept", "application/json")
+	if body != nil {
+		req = req.SetHeader("Content-type", "application/json").SetBody(body)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return errors.Wrapf(err, "%v %v failed", method, path)
+	}
+	if resp.IsError() {
+		return NexusApiError{Method: method, Path: path, StatusCode: resp.StatusCode(), Message: string(resp.Body())}
+	}
+
+	if result != nil && len(resp.Body()) != 0 {
+		if err := json.Unmarshal(resp.Body(), result); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal response of %v %v", method, path)
+		}
+	}
+	return nil
+}
+
+func pathEscape(s string) string {
+	return url.PathEscape(s)
+}
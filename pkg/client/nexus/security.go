@@ -0,0 +1,280 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+const (
+	UserStatusActive   = "active"
+	UserStatusDisabled = "disabled"
+	UserSourceDefault  = "default"
+)
+
+// User is the model of security users API
+type User struct {
+	UserId        string   `json:"userId"`
+	FirstName     string   `json:"firstName"`
+	LastName      string   `json:"lastName"`
+	EmailAddress  string   `json:"emailAddress"`
+	Password      string   `json:"password,omitempty"`
+	Source        string   `json:"source,omitempty"`
+	Status        string   `json:"status"`
+	ReadOnly      bool     `json:"readOnly"`
+	Roles         []string `json:"roles"`
+	ExternalRoles []string `json:"externalRoles,omitempty"`
+}
+
+// Role is the model of security roles API
+type Role struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Source      string   `json:"source,omitempty"`
+	Privileges  []string `json:"privileges"`
+	Roles       []string `json:"roles"`
+}
+
+// Privilege is the model of security privileges API. Type defines the endpoint and the set of used fields
+type Privilege struct {
+	Type            string   `json:"type"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	ReadOnly        bool     `json:"readOnly,omitempty"`
+	Actions         []string `json:"actions,omitempty"`
+	Format          string   `json:"format,omitempty"`
+	Repository      string   `json:"repository,omitempty"`
+	ContentSelector string   `json:"contentSelector,omitempty"`
+	ScriptName      string   `json:"scriptName,omitempty"`
+	Pattern         string   `json:"pattern,omitempty"`
+	Domain          string   `json:"domain,omitempty"`
+}
+
+// ContentSelector is the model of content selectors API, only CSEL selectors can be managed
+type ContentSelector struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description"`
+	Expression  string `json:"expression"`
+}
+
+// Realm is the model of available security realm
+type Realm struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AnonymousAccess is the model of anonymous access settings
+type AnonymousAccess struct {
+	Enabled   bool   `json:"enabled"`
+	UserId    string `json:"userId"`
+	RealmName string `json:"realmName"`
+}
+
+// GetUser returns user by id or nil if it doesn't exist
+func (nc NexusClient) GetUser(userId string) (*User, error) {
+	var out []User
+	if err := nc.doRequest(http.MethodGet, "/security/users?userId="+url.QueryEscape(userId), nil, &out); err != nil {
+		return nil, err
+	}
+	// userId query parameter matches users by prefix
+	for _, u := range out {
+		if u.UserId == userId {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetUsers returns all users of the default realm
+func (nc NexusClient) GetUsers() ([]User, error) {
+	var out []User
+	if err := nc.doRequest(http.MethodGet, "/security/users", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateUser creates user with password
+func (nc NexusClient) CreateUser(user User) error {
+	return nc.doRequest(http.MethodPost, "/security/users", user, nil)
+}
+
+// UpdateUser updates existing user. Password is not changed
+func (nc NexusClient) UpdateUser(user User) error {
+	user.Password = ""
+	return nc.doRequest(http.MethodPut, fmt.Sprintf("/security/users/%v", pathEscape(user.UserId)), user, nil)
+}
+
+// DeleteUser deletes user by id
+func (nc NexusClient) DeleteUser(userId string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/security/users/%v", pathEscape(userId)), nil, nil)
+}
+
+// ChangeUserPassword sets new password for user
+func (nc NexusClient) ChangeUserPassword(userId string, password string) error {
+	path := fmt.Sprintf("/security/users/%v/change-password", pathEscape(userId))
+	resp, err := nc.resty.R().
+		SetHeader("Content-type", "text/plain").
+		SetBody(password).
+		Put(path)
+	if err != nil {
+		return errors.Wrapf(err, "Changing password of user %v failed", userId)
+	}
+	if resp.IsError() {
+		return NexusApiError{Method: http.MethodPut, Path: path, StatusCode: resp.StatusCode(), Message: string(resp.Body())}
+	}
+	return nil
+}
+
+// GetRole returns role by id or nil if it doesn't exist
+func (nc NexusClient) GetRole(id string) (*Role, error) {
+	var out Role
+	err := nc.doRequest(http.MethodGet, fmt.Sprintf("/security/roles/%v", pathEscape(id)), nil, &out)
+	if err != nil {
+		// 404 is returned both for absent role and for unsupported API
+		if IsErrNotFound(err) {
+			supported, checkErr := nc.IsApiSupported(RolesApi)
+			if checkErr != nil {
+				return nil, checkErr
+			}
+			if supported {
+				return nil, nil
+			}
+		}
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRoles returns all roles of the default source
+func (nc NexusClient) GetRoles() ([]Role, error) {
+	var out []Role
+	if err := nc.doRequest(http.MethodGet, "/security/roles", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateRole creates role
+func (nc NexusClient) CreateRole(role Role) error {
+	return nc.doRequest(http.MethodPost, "/security/roles", role, nil)
+}
+
+// UpdateRole updates existing role
+func (nc NexusClient) UpdateRole(role Role) error {
+	return nc.doRequest(http.MethodPut, fmt.Sprintf("/security/roles/%v", pathEscape(role.Id)), role, nil)
+}
+
+// DeleteRole deletes role by id
+func (nc NexusClient) DeleteRole(id string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/security/roles/%v", pathEscape(id)), nil, nil)
+}
+
+// GetPrivileges returns all privileges
+func (nc NexusClient) GetPrivileges() ([]Privilege, error) {
+	var out []Privilege
+	if err := nc.doRequest(http.MethodGet, "/security/privileges", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPrivilege returns privilege by name or nil if it doesn't exist
+func (nc NexusClient) GetPrivilege(name string) (*Privilege, error) {
+	privileges, err := nc.GetPrivileges()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range privileges {
+		if p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreatePrivilege creates privilege of the given type
+func (nc NexusClient) CreatePrivilege(privilege Privilege) error {
+	return nc.doRequest(http.MethodPost, fmt.Sprintf("/security/privileges/%v", privilege.Type), privilege, nil)
+}
+
+// UpdatePrivilege updates existing privilege of the given type
+func (nc NexusClient) UpdatePrivilege(privilege Privilege) error {
+	return nc.doRequest(http.MethodPut,
+		fmt.Sprintf("/security/privileges/%v/%v", privilege.Type, pathEscape(privilege.Name)), privilege, nil)
+}
+
+// DeletePrivilege deletes privilege by name
+func (nc NexusClient) DeletePrivilege(name string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/security/privileges/%v", pathEscape(name)), nil, nil)
+}
+
+// GetContentSelector returns content selector by name or nil if it doesn't exist
+func (nc NexusClient) GetContentSelector(name string) (*ContentSelector, error) {
+	var out []ContentSelector
+	if err := nc.doRequest(http.MethodGet, "/security/content-selectors", nil, &out); err != nil {
+		return nil, err
+	}
+	for _, c := range out {
+		if c.Name == name {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateContentSelector creates content selector
+func (nc NexusClient) CreateContentSelector(selector ContentSelector) error {
+	return nc.doRequest(http.MethodPost, "/security/content-selectors", selector, nil)
+}
+
+// UpdateContentSelector updates description and expression of existing content selector
+func (nc NexusClient) UpdateContentSelector(selector ContentSelector) error {
+	return nc.doRequest(http.MethodPut,
+		fmt.Sprintf("/security/content-selectors/%v", pathEscape(selector.Name)), selector, nil)
+}
+
+// DeleteContentSelector deletes content selector by name
+func (nc NexusClient) DeleteContentSelector(name string) error {
+	return nc.doRequest(http.MethodDelete, fmt.Sprintf("/security/content-selectors/%v", pathEscape(name)), nil, nil)
+}
+
+// GetAvailableRealms returns all realms which can be activated
+func (nc NexusClient) GetAvailableRealms() ([]Realm, error) {
+	var out []Realm
+	if err := nc.doRequest(http.MethodGet, "/security/realms/available", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetActiveRealms returns ids of active realms in the order of their priority
+func (nc NexusClient) GetActiveRealms() ([]string, error) {
+	var out []string
+	if err := nc.doRequest(http.MethodGet, "/security/realms/active", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetActiveRealms activates realms in the given order and deactivates the rest
+func (nc NexusClient) SetActiveRealms(realms []string) error {
+	return nc.doRequest(http.MethodPut, "/security/realms/active", realms, nil)
+}
+
+// GetAnonymousAccess returns anonymous access settings
+func (nc NexusClient) GetAnonymousAccess() (*AnonymousAccess, error) {
+	var out AnonymousAccess
+	if err := nc.doRequest(http.MethodGet, "/security/anonymous", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetAnonymousAccess updates anonymous access settings
+func (nc NexusClient) SetAnonymousAccess(access AnonymousAccess) error {
+	return nc.doRequest(http.MethodPut, "/security/anonymous", access, nil)
+}
@@ -0,0 +1,65 @@
+package nexus
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Task is the model of scheduled task returned by tasks API
+type Task struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Message       string `json:"message"`
+	CurrentState  string `json:"currentState"`
+	LastRunResult string `json:"lastRunResult"`
+	NextRun       string `json:"nextRun"`
+	LastRun       string `json:"lastRun"`
+}
+
+type taskPage struct {
+	Items             []Task `json:"items"`
+	ContinuationToken string `json:"continuationToken"`
+}
+
+// GetTasks returns all scheduled tasks
+func (nc NexusClient) GetTasks() ([]Task, error) {
+	var tasks []Task
+	path := "/tasks"
+	for {
+		var page taskPage
+		if err := nc.doRequest(http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page.Items...)
+		if len(page.ContinuationToken) == 0 {
+			return tasks, nil
+		}
+		path = "/tasks?continuationToken=" + url.QueryEscape(page.ContinuationToken)
+	}
+}
+
+// GetTaskByName returns scheduled task by name or nil if it doesn't exist
+func (nc NexusClient) GetTaskByName(name string) (*Task, error) {
+	tasks, err := nc.GetTasks()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		if t.Name == name {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+// RunTask starts scheduled task by id
+func (nc NexusClient) RunTask(id string) error {
+	return nc.doRequest(http.MethodPost, fmt.Sprintf("/tasks/%v/run", pathEscape(id)), nil, nil)
+}
+
+// StopTask stops running task by id
+func (nc NexusClient) StopTask(id string) error {
+	return nc.doRequest(http.MethodPost, fmt.Sprintf("/tasks/%v/stop", pathEscape(id)), nil, nil)
+}
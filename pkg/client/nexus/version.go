@@ -0,0 +1,37 @@
+package nexus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is Nexus version without build number
+type Version [3]int
+
+// Less reports whether the version is older than the other one
+func (v Version) Less(other Version) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+// ParseVersion parses versions like 3.29.0 or 3.29.0-02, build number is ignored
+func ParseVersion(version string) (Version, error) {
+	var out Version
+	parts := strings.Split(strings.SplitN(version, "-", 2)[0], ".")
+	if len(parts) != len(out) {
+		return out, fmt.Errorf("version %v is not in <major>.<minor>.<patch> format", version)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return out, fmt.Errorf("version %v is not in <major>.<minor>.<patch> format", version)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
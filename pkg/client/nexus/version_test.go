@@ -0,0 +1,30 @@
+package nexus
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Version
+		wantErr bool
+	}{
+		{version: "3.29.0", want: Version{3, 29, 0}},
+		{version: "3.29.0-02", want: Version{3, 29, 0}},
+		{version: "3.70.1-02-java11", want: Version{3, 70, 1}},
+		{version: "3.29", wantErr: true},
+		{version: "3.29.0.1", wantErr: true},
+		{version: "3.x.0", wantErr: true},
+		{version: "latest", wantErr: true},
+		{version: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.version)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
@@ -9,3 +9,24 @@ func GetPlatformTypeEnv() string {
 	return os.Getenv(platformType)
 }
 
+
+// ContainsString checks if the string is present in the slice
+func ContainsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveString returns a copy of the slice without the given string
+func RemoveString(slice []string, s string) (result []string) {
+	for _, item := range slice {
+		if item == s {
+			continue
+		}
+		result = append(result, item)
+	}
+	return
+}
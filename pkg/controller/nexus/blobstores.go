@@ -0,0 +1,114 @@
+package nexus
+
+import (
+	"fmt"
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	coreV1Api "k8s.io/api/core/v1"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"strings"
+)
+
+var (
+	blobStoreLabels = []string{"namespace", "nexus", "blob_store"}
+
+	blobStoreTotalSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nexus_blob_store_total_size_bytes",
+		Help: "Total size of blobs in Nexus blob store",
+	}, blobStoreLabels)
+	blobStoreAvailableSpace = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nexus_blob_store_available_space_bytes",
+		Help: "Space available to Nexus blob store",
+	}, blobStoreLabels)
+	blobStoreBlobCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nexus_blob_store_blob_count",
+		Help: "Number of blobs in Nexus blob store",
+	}, blobStoreLabels)
+	blobStoreSoftQuotaLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nexus_blob_store_soft_quota_limit_bytes",
+		Help: "Limit of soft quota of Nexus blob store",
+	}, blobStoreLabels)
+	blobStoreSoftQuotaViolated = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nexus_blob_store_soft_quota_violated",
+		Help: "Whether soft quota of Nexus blob store is violated (1) or not (0)",
+	}, blobStoreLabels)
+)
+
+func init() {
+	// Metrics of controller-runtime registry are served by manager on metrics port of operator
+	metrics.Registry.MustRegister(blobStoreTotalSize, blobStoreAvailableSpace, blobStoreBlobCount,
+		blobStoreSoftQuotaLimit, blobStoreSoftQuotaViolated)
+}
+
+// setBlobStoreMetrics publishes usage of blob stores and removes metrics of blob stores which are gone
+func setBlobStoreMetrics(instance *edpv1alpha1.Nexus, usage []edpv1alpha1.NexusBlobStoreStatus) {
+	present := map[string]bool{}
+	for _, b := range usage {
+		present[b.Name] = true
+		labels := prometheus.Labels{"namespace": instance.Namespace, "nexus": instance.Name, "blob_store": b.Name}
+		blobStoreTotalSize.With(labels).Set(float64(b.TotalSizeInBytes))
+		blobStoreAvailableSpace.With(labels).Set(float64(b.AvailableSpaceInBytes))
+		blobStoreBlobCount.With(labels).Set(float64(b.BlobCount))
+		if b.SoftQuota == nil {
+			blobStoreSoftQuotaLimit.Delete(labels)
+			blobStoreSoftQuotaViolated.Delete(labels)
+			continue
+		}
+		violated := 0.0
+		if b.SoftQuota.Violated {
+			violated = 1
+		}
+		blobStoreSoftQuotaLimit.With(labels).Set(float64(b.SoftQuota.LimitInBytes))
+		blobStoreSoftQuotaViolated.With(labels).Set(violated)
+	}
+	for _, b := range instance.Status.BlobStores {
+		if !present[b.Name] {
+			deleteBlobStoreMetrics(instance.Namespace, instance.Name, b.Name)
+		}
+	}
+}
+
+// deleteBlobStoreMetrics removes all metrics of blob store
+func deleteBlobStoreMetrics(namespace string, nexusName string, blobStore string) {
+	labels := prometheus.Labels{"namespace": namespace, "nexus": nexusName, "blob_store": blobStore}
+	for _, g := range []*prometheus.GaugeVec{blobStoreTotalSize, blobStoreAvailableSpace, blobStoreBlobCount,
+		blobStoreSoftQuotaLimit, blobStoreSoftQuotaViolated} {
+		g.Delete(labels)
+	}
+}
+
+// getViolatedQuotas returns messages of violated soft quotas of blob stores
+func getViolatedQuotas(instance edpv1alpha1.Nexus) []string {
+	var violated []string
+	for _, b := range instance.Status.BlobStores {
+		if b.SoftQuota != nil && b.SoftQuota.Violated {
+			violated = append(violated, fmt.Sprintf("%v: %v", b.Name, b.SoftQuota.Message))
+		}
+	}
+	return violated
+}
+
+// updateBlobStoreStatus refreshes usage of blob stores in status and metrics. Nexus is marked as degraded and
+// Warning event is emitted while soft quota of any blob store is violated
+func (r *ReconcileNexus) updateBlobStoreStatus(instance *edpv1alpha1.Nexus) error {
+	usage, err := r.service.GetBlobStoresUsage(*instance)
+	if err != nil {
+		return err
+	}
+	setBlobStoreMetrics(instance, usage)
+
+	changed := !reflect.DeepEqual(instance.Status.BlobStores, usage)
+	instance.Status.BlobStores = usage
+	if violated := getViolatedQuotas(*instance); len(violated) != 0 {
+		message := fmt.Sprintf("Soft quota is violated for blob stores - %v", strings.Join(violated, "; "))
+		if setCondition(instance, edpv1alpha1.NexusConditionDegraded, coreV1Api.ConditionTrue, "BlobStoreQuotaViolated", message) {
+			r.recorder.Event(instance, coreV1Api.EventTypeWarning, "BlobStoreQuotaViolated", message)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.saveStatus(instance)
+}
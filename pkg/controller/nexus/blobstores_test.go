@@ -0,0 +1,222 @@
+package nexus
+
+import (
+	"context"
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"testing"
+)
+
+// usageService returns the given usage of blob stores, other methods of Nexus service are not implemented
+type usageService struct {
+	nexus.NexusService
+	usage []edpv1alpha1.NexusBlobStoreStatus
+}
+
+func (s usageService) GetBlobStoresUsage(instance edpv1alpha1.Nexus) ([]edpv1alpha1.NexusBlobStoreStatus, error) {
+	return s.usage, nil
+}
+
+// statusRecorder counts updates of status, other methods of client are not implemented
+type statusRecorder struct {
+	client.Client
+	updates int
+}
+
+func (c *statusRecorder) Status() client.StatusWriter {
+	return c
+}
+
+func (c *statusRecorder) Update(ctx context.Context, obj runtime.Object) error {
+	c.updates++
+	return nil
+}
+
+func blobStoreStatus(name string, quota *edpv1alpha1.NexusBlobStoreSoftQuotaStatus) edpv1alpha1.NexusBlobStoreStatus {
+	return edpv1alpha1.NexusBlobStoreStatus{Name: name, Type: "File", BlobCount: 10, TotalSizeInBytes: 2000,
+		AvailableSpaceInBytes: 5000, SoftQuota: quota}
+}
+
+func TestGetViolatedQuotas(t *testing.T) {
+	violated := &edpv1alpha1.NexusBlobStoreSoftQuotaStatus{Type: "spaceUsedQuota", LimitInBytes: 1000, Violated: true,
+		Message: "Blob store default is using 2 KB space and has a limit of 1 KB"}
+	tests := []struct {
+		name       string
+		blobStores []edpv1alpha1.NexusBlobStoreStatus
+		want       []string
+	}{
+		{name: "no soft quotas", blobStores: []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil)}},
+		{
+			name: "soft quota is not violated",
+			blobStores: []edpv1alpha1.NexusBlobStoreStatus{
+				blobStoreStatus("default", &edpv1alpha1.NexusBlobStoreSoftQuotaStatus{Type: "spaceUsedQuota", LimitInBytes: 3000}),
+			},
+		},
+		{
+			name:       "violated soft quota",
+			blobStores: []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", violated), blobStoreStatus("npm", nil)},
+			want:       []string{"default: Blob store default is using 2 KB space and has a limit of 1 KB"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := edpv1alpha1.Nexus{Status: edpv1alpha1.NexusStatus{BlobStores: tt.blobStores}}
+			if got := getViolatedQuotas(instance); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getViolatedQuotas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetBlobStoreMetrics(t *testing.T) {
+	quota := &edpv1alpha1.NexusBlobStoreSoftQuotaStatus{Type: "spaceUsedQuota", LimitInBytes: 1000, Violated: true}
+	labels := func(nexusName string, blobStore string) prometheus.Labels {
+		return prometheus.Labels{"namespace": "edp", "nexus": nexusName, "blob_store": blobStore}
+	}
+	tests := []struct {
+		name        string
+		previous    []edpv1alpha1.NexusBlobStoreStatus
+		usage       []edpv1alpha1.NexusBlobStoreStatus
+		wantPresent map[*prometheus.GaugeVec]map[string]float64
+		wantAbsent  map[*prometheus.GaugeVec][]string
+	}{
+		{
+			name:  "usage and violated soft quota are published",
+			usage: []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", quota)},
+			wantPresent: map[*prometheus.GaugeVec]map[string]float64{
+				blobStoreTotalSize:         {"default": 2000},
+				blobStoreAvailableSpace:    {"default": 5000},
+				blobStoreBlobCount:         {"default": 10},
+				blobStoreSoftQuotaLimit:    {"default": 1000},
+				blobStoreSoftQuotaViolated: {"default": 1},
+			},
+		},
+		{
+			name:        "metrics of removed soft quota are deleted",
+			previous:    []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", quota)},
+			usage:       []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil)},
+			wantPresent: map[*prometheus.GaugeVec]map[string]float64{blobStoreTotalSize: {"default": 2000}},
+			wantAbsent: map[*prometheus.GaugeVec][]string{
+				blobStoreSoftQuotaLimit:    {"default"},
+				blobStoreSoftQuotaViolated: {"default"},
+			},
+		},
+		{
+			name:        "metrics of removed blob store are deleted",
+			previous:    []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil), blobStoreStatus("npm", quota)},
+			usage:       []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil)},
+			wantPresent: map[*prometheus.GaugeVec]map[string]float64{blobStoreBlobCount: {"default": 10}},
+			wantAbsent: map[*prometheus.GaugeVec][]string{
+				blobStoreTotalSize:         {"npm"},
+				blobStoreAvailableSpace:    {"npm"},
+				blobStoreBlobCount:         {"npm"},
+				blobStoreSoftQuotaLimit:    {"npm"},
+				blobStoreSoftQuotaViolated: {"npm"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Each case publishes metrics of its own Nexus since metrics are global
+			instance := &edpv1alpha1.Nexus{ObjectMeta: metav1.ObjectMeta{Name: tt.name, Namespace: "edp"}}
+			if tt.previous != nil {
+				setBlobStoreMetrics(instance, tt.previous)
+				instance.Status.BlobStores = tt.previous
+			}
+			setBlobStoreMetrics(instance, tt.usage)
+
+			for gauge, values := range tt.wantPresent {
+				for blobStore, want := range values {
+					if got := testutil.ToFloat64(gauge.With(labels(instance.Name, blobStore))); got != want {
+						t.Errorf("setBlobStoreMetrics() metric of %v = %v, want %v", blobStore, got, want)
+					}
+				}
+			}
+			for gauge, blobStores := range tt.wantAbsent {
+				for _, blobStore := range blobStores {
+					if gauge.Delete(labels(instance.Name, blobStore)) {
+						t.Errorf("setBlobStoreMetrics() metric of %v is present, want it to be deleted", blobStore)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateBlobStoreStatus(t *testing.T) {
+	violated := &edpv1alpha1.NexusBlobStoreSoftQuotaStatus{Type: "spaceUsedQuota", LimitInBytes: 1000, Violated: true,
+		Message: "limit is exceeded"}
+	tests := []struct {
+		name         string
+		previous     []edpv1alpha1.NexusBlobStoreStatus
+		conditions   []edpv1alpha1.NexusCondition
+		usage        []edpv1alpha1.NexusBlobStoreStatus
+		wantSaved    bool
+		wantEvents   int
+		wantDegraded coreV1Api.ConditionStatus
+	}{
+		{
+			name:     "unchanged usage is not saved",
+			previous: []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil)},
+			usage:    []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil)},
+		},
+		{
+			name:      "changed usage is saved",
+			usage:     []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil)},
+			wantSaved: true,
+		},
+		{
+			name:         "violated soft quota degrades Nexus",
+			previous:     []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", nil)},
+			conditions:   []edpv1alpha1.NexusCondition{{Type: edpv1alpha1.NexusConditionDegraded, Status: coreV1Api.ConditionFalse}},
+			usage:        []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", violated)},
+			wantSaved:    true,
+			wantEvents:   1,
+			wantDegraded: coreV1Api.ConditionTrue,
+		},
+		{
+			name:     "event is not repeated while soft quota is violated",
+			previous: []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", violated)},
+			conditions: []edpv1alpha1.NexusCondition{{Type: edpv1alpha1.NexusConditionDegraded, Status: coreV1Api.ConditionTrue,
+				Reason: "BlobStoreQuotaViolated", Message: "Soft quota is violated for blob stores - default: limit is exceeded"}},
+			usage:        []edpv1alpha1.NexusBlobStoreStatus{blobStoreStatus("default", violated)},
+			wantDegraded: coreV1Api.ConditionTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := &statusRecorder{}
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileNexus{client: k8sClient, service: usageService{usage: tt.usage}, recorder: recorder}
+			instance := &edpv1alpha1.Nexus{ObjectMeta: metav1.ObjectMeta{Name: tt.name, Namespace: "edp"}}
+			instance.Status.BlobStores = tt.previous
+			instance.Status.Conditions = tt.conditions
+
+			if err := r.updateBlobStoreStatus(instance); err != nil {
+				t.Fatalf("updateBlobStoreStatus() error = %v", err)
+			}
+			if saved := k8sClient.updates != 0; saved != tt.wantSaved {
+				t.Errorf("updateBlobStoreStatus() saved = %v, want %v", saved, tt.wantSaved)
+			}
+			if len(recorder.Events) != tt.wantEvents {
+				t.Errorf("updateBlobStoreStatus() events = %v, want %v", len(recorder.Events), tt.wantEvents)
+			}
+			if !reflect.DeepEqual(instance.Status.BlobStores, tt.usage) {
+				t.Errorf("updateBlobStoreStatus() blob stores = %+v, want %+v", instance.Status.BlobStores, tt.usage)
+			}
+			for _, c := range instance.Status.Conditions {
+				if c.Type == edpv1alpha1.NexusConditionDegraded && c.Status != tt.wantDegraded {
+					t.Errorf("updateBlobStoreStatus() Degraded = %v, want %v", c.Status, tt.wantDegraded)
+				}
+			}
+		})
+	}
+}
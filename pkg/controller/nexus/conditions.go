@@ -0,0 +1,110 @@
+package nexus
+
+import (
+	"context"
+	"fmt"
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	errorsf "github.com/pkg/errors"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setCondition sets condition of the given type and reports whether it has been changed.
+// Transition time is changed only when status of the condition changes
+func setCondition(instance *edpv1alpha1.Nexus, conditionType edpv1alpha1.NexusConditionType,
+	status coreV1Api.ConditionStatus, reason string, message string) bool {
+	for i := range instance.Status.Conditions {
+		c := &instance.Status.Conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status == status && c.Reason == reason && c.Message == message {
+			return false
+		}
+		if c.Status != status {
+			c.LastTransitionTime = metav1.Now()
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		return true
+	}
+
+	instance.Status.Conditions = append(instance.Status.Conditions, edpv1alpha1.NexusCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
+
+func (r *ReconcileNexus) saveStatus(instance *edpv1alpha1.Nexus) error {
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		return r.client.Update(context.TODO(), instance)
+	}
+	return nil
+}
+
+// updateCondition sets condition, saves status and emits Normal event if condition has been changed
+func (r *ReconcileNexus) updateCondition(instance *edpv1alpha1.Nexus, conditionType edpv1alpha1.NexusConditionType,
+	status coreV1Api.ConditionStatus, reason string, message string) error {
+	if !setCondition(instance, conditionType, status, reason, message) {
+		return nil
+	}
+	if err := r.saveStatus(instance); err != nil {
+		return errorsf.Wrapf(err, "couldn't update condition %v to %v", conditionType, status)
+	}
+	r.recorder.Event(instance, coreV1Api.EventTypeNormal, reason, message)
+	return nil
+}
+
+// markFailed sets condition to False, marks Nexus as degraded and emits Warning event with the cause of failure
+func (r *ReconcileNexus) markFailed(instance *edpv1alpha1.Nexus, conditionType edpv1alpha1.NexusConditionType,
+	reason string, err error) {
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+	r.recorder.Event(instance, coreV1Api.EventTypeWarning, reason, err.Error())
+
+	changed := setCondition(instance, conditionType, coreV1Api.ConditionFalse, reason, err.Error())
+	changed = setCondition(instance, edpv1alpha1.NexusConditionDegraded, coreV1Api.ConditionTrue, reason, err.Error()) || changed
+	if !changed {
+		return
+	}
+	if err := r.saveStatus(instance); err != nil {
+		reqLogger.Error(err, "couldn't update conditions", "Condition", conditionType)
+	}
+}
+
+// updateLdapCondition sets LdapReachable condition from result of reachability test of LDAP server.
+// Failed test doesn't stop reconciliation since Nexus may still be used by local users
+func (r *ReconcileNexus) updateLdapCondition(instance *edpv1alpha1.Nexus) error {
+	if instance.Spec.Ldap == nil {
+		if !removeCondition(instance, edpv1alpha1.NexusConditionLdapReachable) {
+			return nil
+		}
+		return r.saveStatus(instance)
+	}
+
+	if err := r.service.CheckLdapReachability(*instance); err != nil {
+		if setCondition(instance, edpv1alpha1.NexusConditionLdapReachable, coreV1Api.ConditionFalse, "LdapUnreachable", err.Error()) {
+			r.recorder.Event(instance, coreV1Api.EventTypeWarning, "LdapUnreachable", err.Error())
+			return r.saveStatus(instance)
+		}
+		return nil
+	}
+	return r.updateCondition(instance, edpv1alpha1.NexusConditionLdapReachable, coreV1Api.ConditionTrue,
+		"LdapReachable", fmt.Sprintf("LDAP server %v accepts connections, bind credentials are checked by Nexus", instance.Spec.Ldap.Host))
+}
+
+// removeCondition removes condition of the given type and reports whether it has been present
+func removeCondition(instance *edpv1alpha1.Nexus, conditionType edpv1alpha1.NexusConditionType) bool {
+	for i, c := range instance.Status.Conditions {
+		if c.Type == conditionType {
+			instance.Status.Conditions = append(instance.Status.Conditions[:i], instance.Status.Conditions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package nexus
+
+import (
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	coreV1Api "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestSetCondition(t *testing.T) {
+	installed := edpv1alpha1.NexusCondition{Type: edpv1alpha1.NexusConditionInstalled, Status: coreV1Api.ConditionTrue, Reason: "Installed"}
+	tests := []struct {
+		name           string
+		conditions     []edpv1alpha1.NexusCondition
+		status         coreV1Api.ConditionStatus
+		reason         string
+		wantChanged    bool
+		wantTransition bool
+		wantLen        int
+	}{
+		{
+			name:           "absent condition is added",
+			status:         coreV1Api.ConditionTrue,
+			reason:         "Installed",
+			wantChanged:    true,
+			wantTransition: true,
+			wantLen:        1,
+		},
+		{
+			name:       "same condition",
+			conditions: []edpv1alpha1.NexusCondition{installed},
+			status:     coreV1Api.ConditionTrue,
+			reason:     "Installed",
+			wantLen:    1,
+		},
+		{
+			name:        "changed reason keeps transition time",
+			conditions:  []edpv1alpha1.NexusCondition{installed},
+			status:      coreV1Api.ConditionTrue,
+			reason:      "Upgraded",
+			wantChanged: true,
+			wantLen:     1,
+		},
+		{
+			name:           "changed status updates transition time",
+			conditions:     []edpv1alpha1.NexusCondition{installed},
+			status:         coreV1Api.ConditionFalse,
+			reason:         "Failed",
+			wantChanged:    true,
+			wantTransition: true,
+			wantLen:        1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &edpv1alpha1.Nexus{}
+			instance.Status.Conditions = append(instance.Status.Conditions, tt.conditions...)
+			changed := setCondition(instance, edpv1alpha1.NexusConditionInstalled, tt.status, tt.reason, "")
+			if changed != tt.wantChanged {
+				t.Errorf("setCondition() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if len(instance.Status.Conditions) != tt.wantLen {
+				t.Fatalf("setCondition() conditions = %v, want %v", len(instance.Status.Conditions), tt.wantLen)
+			}
+			c := instance.Status.Conditions[0]
+			if c.Status != tt.status || c.Reason != tt.reason {
+				t.Errorf("setCondition() condition = %v/%v, want %v/%v", c.Status, c.Reason, tt.status, tt.reason)
+			}
+			if transition := !c.LastTransitionTime.IsZero(); transition != tt.wantTransition {
+				t.Errorf("setCondition() transition time is set = %v, want %v", transition, tt.wantTransition)
+			}
+		})
+	}
+}
@@ -8,13 +8,16 @@ import (
 	"github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus"
 	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform"
 	"os"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"time"
 
 	errorsf "github.com/pkg/errors"
+	coreV1Api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -35,6 +38,13 @@ const (
 	StatusExposeFinish     = "config exposed"
 	StatusIntegrationStart = "integration started"
 	StatusReady            = "ready"
+
+	//NexusFinalizerName finalizer which keeps Nexus CR until resources not owned by it are removed
+	NexusFinalizerName = "nexus.finalizer.name"
+
+	//BlobStoreUsageRefreshInterval is a period of reconciliation of ready Nexus which refreshes usage of blob stores
+	//and status of tasks
+	BlobStoreUsageRefreshInterval = 5 * time.Minute
 )
 
 var log = logf.Log.WithName("controller_nexus")
@@ -64,9 +74,10 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 	nexusService := nexus.NewNexusService(platformService, client)
 
 	return &ReconcileNexus{
-		client:  client,
-		scheme:  scheme,
-		service: nexusService,
+		client:   client,
+		scheme:   scheme,
+		service:  nexusService,
+		recorder: mgr.GetRecorder("nexus-controller"),
 	}
 }
 
@@ -82,7 +93,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			oldObject := e.ObjectOld.(*edpv1alpha1.Nexus)
 			newObject := e.ObjectNew.(*edpv1alpha1.Nexus)
-			if oldObject.Status != newObject.Status {
+			if !reflect.DeepEqual(oldObject.Status, newObject.Status) {
 				return false
 			}
 			return true
@@ -104,9 +115,10 @@ var _ reconcile.Reconciler = &ReconcileNexus{}
 type ReconcileNexus struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	client  client.Client
-	scheme  *runtime.Scheme
-	service nexus.NexusService
+	client   client.Client
+	scheme   *runtime.Scheme
+	service  nexus.NexusService
+	recorder record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a Nexus object and makes changes based on the state read
@@ -133,7 +145,23 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 		return reconcile.Result{}, err
 	}
 
-	if instance.Status.Status == "" || instance.Status.Status == StatusFailed {
+	if instance.DeletionTimestamp != nil {
+		return r.cleanup(instance)
+	}
+
+	if err := r.setFinalizer(instance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Setting finalizer has been failed")
+	}
+
+	if isUpgradeRequested(instance) {
+		return r.upgrade(instance)
+	}
+
+	if instance.Status.Status == StatusReady && isTaskRunRequested(instance) {
+		return r.runTask(instance)
+	}
+
+	if instance.Status.Status == "" || instance.Status.Status == StatusFailed || instance.Status.Status == StatusUpgradeFailed {
 		reqLogger.Info("Installation has been started")
 		err = r.updateStatus(instance, StatusInstall)
 		if err != nil {
@@ -143,10 +171,21 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 
 	instance, err = r.service.Install(*instance)
 	if err != nil {
+		r.markFailed(instance, edpv1alpha1.NexusConditionInstalled, "InstallationFailed", err)
 		r.updateStatus(instance, StatusFailed)
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Installation has been failed")
 	}
 
+	err = r.updateCondition(instance, edpv1alpha1.NexusConditionInstalled, coreV1Api.ConditionTrue,
+		"InstallationSucceeded", "Nexus resources have been installed")
+	if err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+
+	if err = r.setCurrentVersion(instance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Saving deployed version has been failed")
+	}
+
 	if instance.Status.Status == StatusInstall {
 		reqLogger.Info("Installation has been finished")
 		r.updateStatus(instance, StatusCreated)
@@ -154,10 +193,19 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 	}
 
 	if ready, err := r.service.IsDeploymentReady(*instance); err != nil {
+		r.markFailed(instance, edpv1alpha1.NexusConditionDeploymentReady, "DeploymentCheckFailed", err)
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Checking if Deployment config is ready has been failed")
 	} else if !*ready {
 		reqLogger.Info("Deployment config is not ready for configuration yet")
-		return reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		err = r.updateCondition(instance, edpv1alpha1.NexusConditionDeploymentReady, coreV1Api.ConditionFalse,
+			"DeploymentNotReady", "Nexus deployment is not ready yet")
+		return reconcile.Result{RequeueAfter: 60 * time.Second}, err
+	}
+
+	err = r.updateCondition(instance, edpv1alpha1.NexusConditionDeploymentReady, coreV1Api.ConditionTrue,
+		"DeploymentReady", "Nexus deployment is ready")
+	if err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
 	}
 
 	if instance.Status.Status == StatusCreated || instance.Status.Status == "" {
@@ -171,9 +219,22 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 	instance, isFinished, err := r.service.Configure(*instance)
 	if err != nil {
 		reqLogger.Error(err, "Configuration has failed")
+		r.markFailed(instance, edpv1alpha1.NexusConditionConfigured, "ConfigurationFailed", err)
 		return reconcile.Result{RequeueAfter: 30 * time.Second}, errorsf.Wrap(err, "Configuration failed")
 	} else if !isFinished {
-		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		err = r.updateCondition(instance, edpv1alpha1.NexusConditionConfigured, coreV1Api.ConditionFalse,
+			"ConfigurationInProgress", "Nexus configuration is in progress")
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, err
+	}
+
+	err = r.updateCondition(instance, edpv1alpha1.NexusConditionConfigured, coreV1Api.ConditionTrue,
+		"ConfigurationSucceeded", "Nexus has been configured")
+	if err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+
+	if err = r.updateLdapCondition(instance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
 	}
 
 	if instance.Status.Status == StatusConfiguring {
@@ -194,9 +255,16 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 
 	instance, err = r.service.ExposeConfiguration(*instance)
 	if err != nil {
+		r.markFailed(instance, edpv1alpha1.NexusConditionConfigurationExposed, "ExposingConfigurationFailed", err)
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Exposing configuration failed")
 	}
 
+	err = r.updateCondition(instance, edpv1alpha1.NexusConditionConfigurationExposed, coreV1Api.ConditionTrue,
+		"ConfigurationExposed", "Nexus configuration has been exposed")
+	if err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+
 	if instance.Status.Status == StatusExposeStart {
 		reqLogger.Info("Exposing configuration has finished")
 		err = r.updateStatus(instance, StatusExposeFinish)
@@ -215,9 +283,16 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 
 	instance, err = r.service.Integration(*instance)
 	if err != nil {
+		r.markFailed(instance, edpv1alpha1.NexusConditionIntegrated, "IntegrationFailed", err)
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Integration failed")
 	}
 
+	err = r.updateCondition(instance, edpv1alpha1.NexusConditionIntegrated, coreV1Api.ConditionTrue,
+		"IntegrationSucceeded", "Nexus has been integrated with EDP components")
+	if err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+
 	if instance.Status.Status == StatusIntegrationStart {
 		reqLogger.Info("Exposing configuration has started")
 		err = r.updateStatus(instance, StatusReady)
@@ -226,6 +301,14 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 		}
 	}
 
+	if err = r.updateBlobStoreStatus(instance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Updating usage of blob stores has been failed")
+	}
+
+	if err = r.updateTaskStatus(instance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Updating status of tasks has been failed")
+	}
+
 	err = r.updateAvailableStatus(instance, true)
 	if err != nil {
 		reqLogger.Info("Failed to update availability status")
@@ -233,6 +316,38 @@ func (r *ReconcileNexus) Reconcile(request reconcile.Request) (reconcile.Result,
 	}
 
 	reqLogger.Info("Reconciling has been finished")
+	return reconcile.Result{RequeueAfter: BlobStoreUsageRefreshInterval}, nil
+}
+
+func (r *ReconcileNexus) setFinalizer(instance *edpv1alpha1.Nexus) error {
+	if helper.ContainsString(instance.Finalizers, NexusFinalizerName) {
+		return nil
+	}
+	instance.Finalizers = append(instance.Finalizers, NexusFinalizerName)
+	return r.client.Update(context.TODO(), instance)
+}
+
+// cleanup removes resources which are not garbage collected together with Nexus CR and releases the finalizer
+func (r *ReconcileNexus) cleanup(instance *edpv1alpha1.Nexus) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+	if !helper.ContainsString(instance.Finalizers, NexusFinalizerName) {
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.service.Cleanup(*instance); err != nil {
+		r.recorder.Event(instance, coreV1Api.EventTypeWarning, "CleanupFailed", err.Error())
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Cleanup has been failed")
+	}
+
+	for _, b := range instance.Status.BlobStores {
+		deleteBlobStoreMetrics(instance.Namespace, instance.Name, b.Name)
+	}
+
+	instance.Finalizers = helper.RemoveString(instance.Finalizers, NexusFinalizerName)
+	if err := r.client.Update(context.TODO(), instance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+	reqLogger.Info("Cleanup has been finished")
 	return reconcile.Result{}, nil
 }
 
@@ -249,13 +364,24 @@ func (r *ReconcileNexus) updateStatus(instance *edpv1alpha1.Nexus, newStatus str
 		}
 	}
 	reqLogger.Info(fmt.Sprintf("Status has been updated to '%v'", newStatus))
+	if currentStatus != newStatus {
+		r.recorder.Eventf(instance, coreV1Api.EventTypeNormal, "StatusUpdated", "Status has been changed from '%v' to '%v'", currentStatus, newStatus)
+	}
 	return nil
 }
 
+// updateAvailableStatus sets availability, records reconciled generation and clears degraded condition
+// unless soft quota of a blob store is violated or upgrade to the version from spec has been rejected
 func (r ReconcileNexus) updateAvailableStatus(instance *edpv1alpha1.Nexus, value bool) error {
 	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name).WithName("status_update")
-	if instance.Status.Available != value {
+	degraded := false
+	if len(getViolatedQuotas(*instance)) == 0 && !isUpgradeRejected(instance) {
+		degraded = setCondition(instance, edpv1alpha1.NexusConditionDegraded, coreV1Api.ConditionFalse,
+			"ReconcileSucceeded", "Nexus has been reconciled successfully")
+	}
+	if instance.Status.Available != value || instance.Status.ObservedGeneration != instance.Generation || degraded {
 		instance.Status.Available = value
+		instance.Status.ObservedGeneration = instance.Generation
 		instance.Status.LastTimeUpdated = time.Now()
 		err := r.client.Status().Update(context.TODO(), instance)
 		if err != nil {
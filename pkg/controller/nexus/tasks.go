@@ -0,0 +1,164 @@
+package nexus
+
+import (
+	"context"
+	"fmt"
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	nexusClient "github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	edpHelper "github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	errorsf "github.com/pkg/errors"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"time"
+)
+
+const (
+	//RunTaskAnnotationSuffix - annotation on Nexus CR with name of task which has to be run once
+	RunTaskAnnotationSuffix = "run-task"
+	//TaskRunCheckInterval is a period of checking whether requested task run has been completed
+	TaskRunCheckInterval = 10 * time.Second
+
+	nexusTaskStateRunning = "RUNNING"
+	nexusTaskResultOk     = "OK"
+)
+
+// updateTaskStatus refreshes state and last run result of tasks created by operator in status
+func (r *ReconcileNexus) updateTaskStatus(instance *edpv1alpha1.Nexus) error {
+	tasks, err := r.service.GetTasksStatus(*instance)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(instance.Status.Tasks, tasks) {
+		return nil
+	}
+	instance.Status.Tasks = tasks
+	return r.saveStatus(instance)
+}
+
+// isTaskRunRequested checks if run of task is requested with annotation or requested run has not been completed yet
+func isTaskRunRequested(instance *edpv1alpha1.Nexus) bool {
+	if run := instance.Status.TaskRun; run != nil &&
+		(run.State == edpv1alpha1.TaskRunStatePending || run.State == edpv1alpha1.TaskRunStateRunning) {
+		return true
+	}
+	_, ok := instance.Annotations[edpHelper.GenerateAnnotationKey(RunTaskAnnotationSuffix)]
+	return ok
+}
+
+// runTask starts task requested with annotation and checks periodically whether it has been completed.
+// Pending state is saved before the task is started, so the task is not started twice if reconciliation is
+// interrupted after the start. Result of the run is recorded in status and event, the annotation is removed
+// once the run is over
+func (r *ReconcileNexus) runTask(instance *edpv1alpha1.Nexus) (reconcile.Result, error) {
+	run := instance.Status.TaskRun
+	if run != nil && run.State == edpv1alpha1.TaskRunStateRunning {
+		return r.checkTaskRun(instance)
+	}
+
+	if run == nil || run.State != edpv1alpha1.TaskRunStatePending {
+		name := instance.Annotations[edpHelper.GenerateAnnotationKey(RunTaskAnnotationSuffix)]
+		task, err := r.service.GetTaskStatus(*instance, name)
+		if err != nil && !nexusClient.IsErrNotSupported(err) {
+			return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrapf(err, "Checking state of task %v has been failed", name)
+		}
+
+		instance.Status.TaskRun = &edpv1alpha1.NexusTaskRunStatus{
+			Name:      name,
+			State:     edpv1alpha1.TaskRunStatePending,
+			StartTime: metav1.Now(),
+		}
+		if err != nil {
+			return r.finishTaskRun(instance, edpv1alpha1.TaskRunStateFailed, "", "Tasks API is not supported by running Nexus version")
+		}
+		if task == nil {
+			return r.finishTaskRun(instance, edpv1alpha1.TaskRunStateFailed, "", fmt.Sprintf("Task %v is not found", name))
+		}
+		instance.Status.TaskRun.PreviousLastRun = task.LastRun
+		if err := r.saveStatus(instance); err != nil {
+			return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrap(err, "Saving state of task run has been failed")
+		}
+	} else {
+		// Task may have been started before reconciliation was interrupted, it is not started again in that case
+		task, err := r.service.GetTaskStatus(*instance, run.Name)
+		if err != nil {
+			return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrapf(err, "Checking state of task %v has been failed", run.Name)
+		}
+		if task == nil {
+			return r.finishTaskRun(instance, edpv1alpha1.TaskRunStateFailed, "", fmt.Sprintf("Task %v is not found", run.Name))
+		}
+		if task.CurrentState == nexusTaskStateRunning || task.LastRun != run.PreviousLastRun {
+			return r.markTaskRunStarted(instance)
+		}
+	}
+
+	name := instance.Status.TaskRun.Name
+	if _, err := r.service.RunTask(*instance, name); err != nil {
+		return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrapf(err, "Running task %v has been failed", name)
+	}
+	return r.markTaskRunStarted(instance)
+}
+
+// markTaskRunStarted saves Running state of task run, completion of the run is checked from then on
+func (r *ReconcileNexus) markTaskRunStarted(instance *edpv1alpha1.Nexus) (reconcile.Result, error) {
+	instance.Status.TaskRun.State = edpv1alpha1.TaskRunStateRunning
+	if err := r.saveStatus(instance); err != nil {
+		return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrap(err, "Saving state of task run has been failed")
+	}
+	r.recorder.Eventf(instance, coreV1Api.EventTypeNormal, "TaskRunStarted", "Task %v has been started", instance.Status.TaskRun.Name)
+	return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, nil
+}
+
+// checkTaskRun finishes task run if Nexus reports that task is not running and its last run time has been changed
+func (r *ReconcileNexus) checkTaskRun(instance *edpv1alpha1.Nexus) (reconcile.Result, error) {
+	run := instance.Status.TaskRun
+	task, err := r.service.GetTaskStatus(*instance, run.Name)
+	if err != nil {
+		return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrapf(err, "Checking state of task %v has been failed", run.Name)
+	}
+	if task == nil {
+		return r.finishTaskRun(instance, edpv1alpha1.TaskRunStateFailed, "", fmt.Sprintf("Task %v has been deleted while running", run.Name))
+	}
+	if task.CurrentState == nexusTaskStateRunning || task.LastRun == run.PreviousLastRun {
+		return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, nil
+	}
+	if task.LastRunResult != nexusTaskResultOk {
+		return r.finishTaskRun(instance, edpv1alpha1.TaskRunStateFailed, task.LastRunResult,
+			fmt.Sprintf("Task %v has been finished with result %v", run.Name, task.LastRunResult))
+	}
+	return r.finishTaskRun(instance, edpv1alpha1.TaskRunStateSucceeded, task.LastRunResult,
+		fmt.Sprintf("Task %v has been finished successfully", run.Name))
+}
+
+// finishTaskRun records result of task run in status and event. The annotation is removed before status is saved
+// so that the task is not run again if saving fails, it is kept if another task has been requested meanwhile
+func (r *ReconcileNexus) finishTaskRun(instance *edpv1alpha1.Nexus, state edpv1alpha1.TaskRunState,
+	result string, message string) (reconcile.Result, error) {
+	run := *instance.Status.TaskRun
+	now := metav1.Now()
+	run.State = state
+	run.CompletionTime = &now
+	run.Result = result
+	run.Message = message
+
+	key := edpHelper.GenerateAnnotationKey(RunTaskAnnotationSuffix)
+	if name, ok := instance.Annotations[key]; ok && name == run.Name {
+		delete(instance.Annotations, key)
+		if err := r.client.Update(context.TODO(), instance); err != nil {
+			return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrapf(err, "Removing %v annotation has been failed", key)
+		}
+	}
+
+	instance.Status.TaskRun = &run
+	if err := r.saveStatus(instance); err != nil {
+		return reconcile.Result{RequeueAfter: TaskRunCheckInterval}, errorsf.Wrap(err, "Saving result of task run has been failed")
+	}
+
+	eventType, reason := coreV1Api.EventTypeNormal, "TaskRunSucceeded"
+	if state == edpv1alpha1.TaskRunStateFailed {
+		eventType, reason = coreV1Api.EventTypeWarning, "TaskRunFailed"
+	}
+	r.recorder.Event(instance, eventType, reason, message)
+	return reconcile.Result{Requeue: true}, nil
+}
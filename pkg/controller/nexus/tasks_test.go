@@ -0,0 +1,37 @@
+package nexus
+
+import (
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	edpHelper "github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	"testing"
+)
+
+func TestIsTaskRunRequested(t *testing.T) {
+	annotations := map[string]string{edpHelper.GenerateAnnotationKey(RunTaskAnnotationSuffix): "Compact"}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		state       edpv1alpha1.TaskRunState
+		want        bool
+	}{
+		{name: "not requested"},
+		{name: "requested with annotation", annotations: annotations, want: true},
+		{name: "pending run", state: edpv1alpha1.TaskRunStatePending, want: true},
+		{name: "running task", state: edpv1alpha1.TaskRunStateRunning, want: true},
+		{name: "completed run", state: edpv1alpha1.TaskRunStateSucceeded},
+		{name: "failed run", state: edpv1alpha1.TaskRunStateFailed},
+		{name: "requested again after completed run", annotations: annotations, state: edpv1alpha1.TaskRunStateSucceeded, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &edpv1alpha1.Nexus{}
+			instance.Annotations = tt.annotations
+			if len(tt.state) != 0 {
+				instance.Status.TaskRun = &edpv1alpha1.NexusTaskRunStatus{Name: "Compact", State: tt.state}
+			}
+			if got := isTaskRunRequested(instance); got != tt.want {
+				t.Errorf("isTaskRunRequested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package nexus
+
+import (
+	"context"
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	errorsf "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"time"
+)
+
+const (
+	StatusUpgradeValidating = "validating upgrade"
+	StatusUpgradeReadOnly   = "enabling read-only mode"
+	StatusUpgradeBackup     = "backing up"
+	StatusUpgradeRollout    = "rolling out new version"
+	StatusUpgradeWaiting    = "waiting for new version"
+	StatusUpgradeFailed     = "upgrade failed"
+)
+
+func isUpgradeInProgress(status string) bool {
+	switch status {
+	case StatusUpgradeValidating, StatusUpgradeReadOnly, StatusUpgradeBackup, StatusUpgradeRollout, StatusUpgradeWaiting:
+		return true
+	}
+	return false
+}
+
+// isUpgradeRejected checks if upgrade to the version from spec has failed validation
+func isUpgradeRejected(instance *edpv1alpha1.Nexus) bool {
+	return len(instance.Status.RejectedVersion) != 0 && instance.Status.RejectedVersion == instance.Spec.Version
+}
+
+// isUpgradeRequested checks if deployed Nexus version differs from spec or upgrade has not been finished yet.
+// Rejected upgrade is not retried until spec version is changed
+func isUpgradeRequested(instance *edpv1alpha1.Nexus) bool {
+	if isUpgradeInProgress(instance.Status.Status) {
+		return true
+	}
+	if isUpgradeRejected(instance) {
+		return false
+	}
+	return len(instance.Status.CurrentVersion) != 0 && instance.Status.CurrentVersion != instance.Spec.Version
+}
+
+// upgrade moves Nexus through upgrade phases: validation of upgrade path, switching to read-only mode, backup,
+// rollout of the new version and restoring of write mode once the new version is up
+func (r *ReconcileNexus) upgrade(instance *edpv1alpha1.Nexus) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+
+	if !isUpgradeInProgress(instance.Status.Status) {
+		reqLogger.Info("Upgrade has been started", "From", instance.Status.CurrentVersion, "To", instance.Spec.Version)
+		if err := r.updateStatus(instance, StatusUpgradeValidating); err != nil {
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+		}
+	}
+
+	// Version in spec has been reverted before the new version was rolled out
+	if instance.Status.Status != StatusUpgradeWaiting && instance.Status.CurrentVersion == instance.Spec.Version {
+		reqLogger.Info("Upgrade has been cancelled")
+		return r.finishUpgrade(instance)
+	}
+
+	if instance.Status.Status == StatusUpgradeValidating {
+		if err := r.service.ValidateUpgrade(*instance); err != nil {
+			// Rejection is terminal, the current version keeps running until spec version is changed
+			reqLogger.Error(err, "Upgrade is not possible", "Version", instance.Spec.Version)
+			instance.Status.RejectedVersion = instance.Spec.Version
+			r.markFailed(instance, edpv1alpha1.NexusConditionDegraded, "UpgradeValidationFailed", err)
+			if err := r.updateStatus(instance, StatusUpgradeFailed); err != nil {
+				return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+			}
+			return reconcile.Result{}, nil
+		}
+		instance.Status.RejectedVersion = ""
+		if err := r.updateStatus(instance, StatusUpgradeReadOnly); err != nil {
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+		}
+	}
+
+	if instance.Status.Status == StatusUpgradeReadOnly {
+		if err := r.service.SetReadOnly(*instance, true); err != nil {
+			r.markFailed(instance, edpv1alpha1.NexusConditionDegraded, "ReadOnlyModeFailed", err)
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Enabling read-only mode has been failed")
+		}
+		if err := r.updateStatus(instance, StatusUpgradeBackup); err != nil {
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+		}
+	}
+
+	if instance.Status.Status == StatusUpgradeBackup {
+		done, err := r.service.Backup(*instance)
+		if err != nil {
+			reqLogger.Error(err, "Backup has been failed")
+			r.markFailed(instance, edpv1alpha1.NexusConditionDegraded, "BackupFailed", err)
+			r.service.SetReadOnly(*instance, false)
+			if err := r.updateStatus(instance, StatusUpgradeFailed); err != nil {
+				return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+			}
+			// Error is not returned since it makes RequeueAfter ignored
+			return reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
+		}
+		if !done {
+			reqLogger.Info("Backup is in progress")
+			return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+		if err := r.updateStatus(instance, StatusUpgradeRollout); err != nil {
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+		}
+	}
+
+	if instance.Status.Status == StatusUpgradeRollout {
+		if _, err := r.service.Install(*instance); err != nil {
+			r.markFailed(instance, edpv1alpha1.NexusConditionInstalled, "RolloutFailed", err)
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Rolling out new version has been failed")
+		}
+		instance.Status.CurrentVersion = instance.Spec.Version
+		if err := r.updateStatus(instance, StatusUpgradeWaiting); err != nil {
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+		}
+	}
+
+	upgraded, err := r.service.IsUpgraded(*instance)
+	if err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Checking if new version is up has been failed")
+	}
+	if !upgraded {
+		reqLogger.Info("New version of Nexus is not ready yet", "Version", instance.Status.CurrentVersion)
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	reqLogger.Info("Upgrade has been finished", "Version", instance.Status.CurrentVersion)
+	return r.finishUpgrade(instance)
+}
+
+func (r *ReconcileNexus) finishUpgrade(instance *edpv1alpha1.Nexus) (reconcile.Result, error) {
+	if err := r.service.SetReadOnly(*instance, false); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "Restoring write mode has been failed")
+	}
+	if err := r.updateStatus(instance, StatusReady); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// setCurrentVersion records version of Nexus deployed for the first time
+func (r *ReconcileNexus) setCurrentVersion(instance *edpv1alpha1.Nexus) error {
+	if len(instance.Status.CurrentVersion) != 0 {
+		return nil
+	}
+	instance.Status.CurrentVersion = instance.Spec.Version
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		return r.client.Update(context.TODO(), instance)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package nexusrepository
+
+import (
+	"context"
+	"fmt"
+	edpv1alpha1 "github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform"
+	coreV1Api "k8s.io/api/core/v1"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"time"
+
+	errorsf "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	StatusCreated = "created"
+	StatusFailed  = "failed"
+	StatusWaiting = "waiting for nexus"
+
+	//RepositoryFinalizerName finalizer which keeps NexusRepository CR until repository is removed from Nexus
+	RepositoryFinalizerName = "nexus.repository.finalizer.name"
+)
+
+var log = logf.Log.WithName("controller_nexusrepository")
+
+// Add creates a new NexusRepository Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	scheme := mgr.GetScheme()
+	client := mgr.GetClient()
+	platformType := helper.GetPlatformTypeEnv()
+	platformService, err := platform.NewPlatformService(platformType, scheme, &client)
+	if err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	nexusService := nexus.NewNexusService(platformService, client)
+
+	return &ReconcileNexusRepository{
+		client:  client,
+		scheme:  scheme,
+		service: nexusService,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("nexusrepository-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	p := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObject := e.ObjectOld.(*edpv1alpha1.NexusRepository)
+			newObject := e.ObjectNew.(*edpv1alpha1.NexusRepository)
+			if oldObject.Status != newObject.Status {
+				return false
+			}
+			return true
+		},
+	}
+
+	err = c.Watch(&source.Kind{Type: &edpv1alpha1.NexusRepository{}}, &handler.EnqueueRequestForObject{}, p)
+	if err != nil {
+		return err
+	}
+
+	// Repositories are updated on every reconcile, so rotated signing keys are applied once Secret is changed
+	err = c.Watch(&source.Kind{Type: &coreV1Api.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			return getSigningSecretRequests(mgr.GetClient(), a.Meta.GetNamespace(), a.Meta.GetName())
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getSigningSecretRequests returns requests for NexusRepository CRs which are signed with keys from the given Secret
+func getSigningSecretRequests(c client.Client, namespace string, secretName string) []reconcile.Request {
+	list := &edpv1alpha1.NexusRepositoryList{}
+	if err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, list); err != nil {
+		log.Error(err, "failed to list NexusRepository CRs", "Namespace", namespace)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, repository := range list.Items {
+		if nexus.GetSigningSecretName(repository) == secretName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: repository.Namespace, Name: repository.Name},
+			})
+		}
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileNexusRepository{}
+
+// ReconcileNexusRepository reconciles a NexusRepository object
+type ReconcileNexusRepository struct {
+	client  client.Client
+	scheme  *runtime.Scheme
+	service nexus.NexusService
+}
+
+// Reconcile creates, updates or deletes repository in the Nexus referenced by NexusRepository CR
+func (r *ReconcileNexusRepository) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling has been started")
+
+	instance := &edpv1alpha1.NexusRepository{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	nexusInstance, err := r.getOwnerNexus(instance)
+	if err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, errorsf.Wrap(err, "failed to get owner Nexus")
+	}
+
+	if instance.DeletionTimestamp != nil {
+		return r.deleteRepository(instance, nexusInstance)
+	}
+
+	if nexusInstance == nil {
+		r.updateStatus(instance, StatusFailed, fmt.Sprintf("Nexus %v is not found", instance.Spec.OwnerName))
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if err := r.setOwnerAndFinalizer(instance, nexusInstance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+
+	if !nexusInstance.Status.Available {
+		reqLogger.Info("Nexus is not ready for repository management yet", "Nexus", nexusInstance.Name)
+		r.updateStatus(instance, StatusWaiting, "")
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if err := r.service.CreateOrUpdateRepository(*nexusInstance, *instance); err != nil {
+		r.updateStatus(instance, StatusFailed, err.Error())
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, errorsf.Wrap(err, "failed to create repository")
+	}
+
+	if err := r.updateStatus(instance, StatusCreated, ""); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+
+	reqLogger.Info("Reconciling has been finished")
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileNexusRepository) getOwnerNexus(instance *edpv1alpha1.NexusRepository) (*edpv1alpha1.Nexus, error) {
+	nexusInstance := &edpv1alpha1.Nexus{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{
+		Namespace: instance.Namespace,
+		Name:      instance.Spec.OwnerName,
+	}, nexusInstance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nexusInstance, nil
+}
+
+func (r *ReconcileNexusRepository) setOwnerAndFinalizer(instance *edpv1alpha1.NexusRepository, nexusInstance *edpv1alpha1.Nexus) error {
+	changed := false
+	if len(instance.OwnerReferences) == 0 {
+		if err := controllerutil.SetControllerReference(nexusInstance, instance, r.scheme); err != nil {
+			return errorsf.Wrap(err, "failed to set owner reference")
+		}
+		changed = true
+	}
+	if !helper.ContainsString(instance.Finalizers, RepositoryFinalizerName) {
+		instance.Finalizers = append(instance.Finalizers, RepositoryFinalizerName)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return r.client.Update(context.TODO(), instance)
+}
+
+func (r *ReconcileNexusRepository) deleteRepository(instance *edpv1alpha1.NexusRepository, nexusInstance *edpv1alpha1.Nexus) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+	if !helper.ContainsString(instance.Finalizers, RepositoryFinalizerName) {
+		return reconcile.Result{}, nil
+	}
+
+	// There is nothing to clean up when the owner Nexus is gone together with its data
+	if nexusInstance != nil && nexusInstance.DeletionTimestamp == nil {
+		if err := r.service.DeleteRepository(*nexusInstance, *instance); err != nil {
+			r.updateStatus(instance, StatusFailed, err.Error())
+			return reconcile.Result{RequeueAfter: 30 * time.Second}, errorsf.Wrap(err, "failed to delete repository")
+		}
+		reqLogger.Info("Repository has been deleted from Nexus", "Repository", nexus.GetRepositoryName(*instance))
+	}
+
+	instance.Finalizers = helper.RemoveString(instance.Finalizers, RepositoryFinalizerName)
+	if err := r.client.Update(context.TODO(), instance); err != nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileNexusRepository) updateStatus(instance *edpv1alpha1.NexusRepository, newStatus string, message string) error {
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name).WithName("status_update")
+	currentStatus := instance.Status.Status
+	instance.Status.Status = newStatus
+	instance.Status.Available = newStatus == StatusCreated
+	instance.Status.DetailedMessage = message
+	instance.Status.LastTimeUpdated = time.Now()
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		err := r.client.Update(context.TODO(), instance)
+		if err != nil {
+			return errorsf.Wrapf(err, "couldn't update status from '%v' to '%v'", currentStatus, newStatus)
+		}
+	}
+	reqLogger.Info(fmt.Sprintf("Status has been updated to '%v'", newStatus))
+	return nil
+}
@@ -0,0 +1,74 @@
+package nexus
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+// getTrackedValue reads JSON value of operator annotation with the given suffix into value. Value is left untouched
+// if annotation is absent, false is returned if annotation can not be parsed
+func getTrackedValue(instance v1alpha1.Nexus, annotationSuffix string, value interface{}) bool {
+	data, ok := instance.Annotations[helper.GenerateAnnotationKey(annotationSuffix)]
+	if !ok {
+		return true
+	}
+	if err := json.Unmarshal([]byte(data), value); err != nil {
+		log.Error(err, "failed to parse annotation", "Namespace", instance.Namespace, "Name", instance.Name,
+			"Annotation", annotationSuffix)
+		return false
+	}
+	return true
+}
+
+// getCreatedNames returns names of entities created by operator, stored in Nexus CR annotation as JSON list
+func getCreatedNames(instance v1alpha1.Nexus, annotationSuffix string) []string {
+	var names []string
+	if !getTrackedValue(instance, annotationSuffix, &names) {
+		return nil
+	}
+	return names
+}
+
+// getTrackedHashes returns map of entity name to hash of its configuration, stored in Nexus CR annotation as JSON object
+func getTrackedHashes(instance v1alpha1.Nexus, annotationSuffix string) map[string]string {
+	hashes := map[string]string{}
+	if !getTrackedValue(instance, annotationSuffix, &hashes) {
+		return map[string]string{}
+	}
+	return hashes
+}
+
+// setTrackedValue writes value as JSON into operator annotation with the given suffix. Nexus CR is not updated
+// in cluster, it is done once per reconciliation by saveTrackedAnnotations
+func (n NexusServiceImpl) setTrackedValue(instance *v1alpha1.Nexus, annotationSuffix string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %v annotation", annotationSuffix)
+	}
+	n.setAnnotation(instance, helper.GenerateAnnotationKey(annotationSuffix), string(data))
+	return nil
+}
+
+// copyAnnotations returns copy of Nexus CR annotations to detect changes made during reconciliation
+func copyAnnotations(instance v1alpha1.Nexus) map[string]string {
+	annotations := make(map[string]string, len(instance.Annotations))
+	for k, v := range instance.Annotations {
+		annotations[k] = v
+	}
+	return annotations
+}
+
+// saveTrackedAnnotations updates Nexus CR in cluster if its annotations differ from the original ones. It is deferred
+// by reconciliation steps, so entities created before a failure are still tracked
+func (n NexusServiceImpl) saveTrackedAnnotations(instance *v1alpha1.Nexus, original map[string]string) {
+	if reflect.DeepEqual(copyAnnotations(*instance), original) {
+		return
+	}
+	if err := n.k8sClient.Update(context.TODO(), instance); err != nil {
+		log.Error(err, "failed to save annotations of Nexus CR", "Namespace", instance.Namespace, "Name", instance.Name)
+	}
+}
@@ -0,0 +1,79 @@
+package nexus
+
+import (
+	"context"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"testing"
+)
+
+// updateRecorder counts updates of objects, other methods of client are not implemented
+type updateRecorder struct {
+	client.Client
+	updates int
+}
+
+func (c *updateRecorder) Update(ctx context.Context, obj runtime.Object) error {
+	c.updates++
+	return nil
+}
+
+func TestGetTrackedHashes(t *testing.T) {
+	annotationKey := helper.GenerateAnnotationKey(scriptsAnnotationSuffix)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        map[string]string
+	}{
+		{name: "annotation is absent", want: map[string]string{}},
+		{
+			name:        "hashes from annotation",
+			annotations: map[string]string{annotationKey: `{"setup-role":"1"}`},
+			want:        map[string]string{"setup-role": "1"},
+		},
+		{name: "malformed annotation", annotations: map[string]string{annotationKey: `["setup-role"]`}, want: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := v1alpha1.Nexus{}
+			instance.Annotations = tt.annotations
+			if got := getTrackedHashes(instance, scriptsAnnotationSuffix); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getTrackedHashes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveTrackedAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		names       []string
+		wantUpdates int
+	}{
+		{name: "unchanged annotations are not saved", names: []string{"nexus-admins"}},
+		{name: "changed annotations are saved", names: []string{"nexus-admins", "nexus-readers"}, wantUpdates: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := &updateRecorder{}
+			n := NexusServiceImpl{k8sClient: k8sClient}
+			instance := &v1alpha1.Nexus{}
+			instance.Annotations = map[string]string{helper.GenerateAnnotationKey(roleMappingsAnnotationSuffix): `["nexus-admins"]`}
+			original := copyAnnotations(*instance)
+
+			if err := n.setTrackedValue(instance, roleMappingsAnnotationSuffix, tt.names); err != nil {
+				t.Fatalf("setTrackedValue() error = %v", err)
+			}
+			n.saveTrackedAnnotations(instance, original)
+			if k8sClient.updates != tt.wantUpdates {
+				t.Errorf("saveTrackedAnnotations() updates = %v, want %v", k8sClient.updates, tt.wantUpdates)
+			}
+			if got := getCreatedNames(*instance, roleMappingsAnnotationSuffix); !reflect.DeepEqual(got, tt.names) {
+				t.Errorf("getCreatedNames() = %v, want %v", got, tt.names)
+			}
+		})
+	}
+}
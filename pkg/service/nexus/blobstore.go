@@ -0,0 +1,280 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// s3CredentialsAnnotationSuffix - annotation on Nexus CR which keeps hashes of credentials of S3 blob stores, they are
+// not returned by REST API and can not be compared with the ones from Secret
+const s3CredentialsAnnotationSuffix = "s3-credentials"
+
+// getBlobStoreType returns type of blob store from spec with default applied
+func getBlobStoreType(blobStore v1alpha1.NexusBlobStore) v1alpha1.BlobStoreType {
+	if len(blobStore.Type) == 0 {
+		return v1alpha1.BlobStoreTypeFile
+	}
+	return blobStore.Type
+}
+
+// validateBlobStore checks that settings of blob store match its type
+func validateBlobStore(blobStore v1alpha1.NexusBlobStore) error {
+	switch getBlobStoreType(blobStore) {
+	case v1alpha1.BlobStoreTypeFile:
+		if blobStore.S3 != nil {
+			return errors.New("S3 settings can not be set for File blob store")
+		}
+	case v1alpha1.BlobStoreTypeS3:
+		if blobStore.S3 == nil || len(blobStore.S3.Bucket) == 0 {
+			return errors.New("bucket is mandatory for S3 blob store")
+		}
+		if len(blobStore.Path) != 0 {
+			return errors.New("path can not be set for S3 blob store")
+		}
+	default:
+		return fmt.Errorf("blob store type %v is not supported", blobStore.Type)
+	}
+	if quota := blobStore.SoftQuota; quota != nil {
+		if quota.Type != nexusDefaultSpec.SoftQuotaTypeSpaceUsed && quota.Type != nexusDefaultSpec.SoftQuotaTypeSpaceRemaining {
+			return fmt.Errorf("soft quota type %v is not supported", quota.Type)
+		}
+		if quota.Limit.Value() < bytesInMegabyte {
+			return errors.New("soft quota limit must be at least 1M")
+		}
+	}
+	return nil
+}
+
+// bytesInMegabyte - soft quota limit is set in megabytes in REST API
+const bytesInMegabyte = 1000 * 1000
+
+// getBlobStoreSoftQuota returns soft quota of blob store in the form of REST API or nil if it is not set
+func getBlobStoreSoftQuota(blobStore v1alpha1.NexusBlobStore) *nexus.BlobStoreSoftQuota {
+	if blobStore.SoftQuota == nil {
+		return nil
+	}
+	return &nexus.BlobStoreSoftQuota{
+		Type:  blobStore.SoftQuota.Type,
+		Limit: blobStore.SoftQuota.Limit.Value() / bytesInMegabyte,
+	}
+}
+
+// getS3BlobStore returns S3 blob store from spec with defaults applied, credentials are read from Secret
+func (n NexusServiceImpl) getS3BlobStore(instance v1alpha1.Nexus, blobStore v1alpha1.NexusBlobStore) (*nexus.S3BlobStore, error) {
+	s3 := *blobStore.S3
+	bucket := nexus.S3BlobStoreBucket{
+		Region:     s3.Region,
+		Name:       s3.Bucket,
+		Prefix:     s3.Prefix,
+		Expiration: nexusDefaultSpec.NexusDefaultS3BlobStoreExpiration,
+	}
+	if len(bucket.Region) == 0 {
+		bucket.Region = nexusDefaultSpec.NexusDefaultS3BlobStoreRegion
+	}
+	if s3.Expiration != nil {
+		bucket.Expiration = *s3.Expiration
+	}
+
+	result := nexus.S3BlobStore{
+		Name:                blobStore.Name,
+		SoftQuota:           getBlobStoreSoftQuota(blobStore),
+		BucketConfiguration: nexus.S3BlobStoreBucketConfiguration{Bucket: bucket},
+	}
+	if len(s3.Endpoint) != 0 || s3.ForcePathStyle {
+		result.BucketConfiguration.AdvancedBucketConnection = &nexus.S3BlobStoreAdvancedBucketConnection{
+			Endpoint:       s3.Endpoint,
+			ForcePathStyle: s3.ForcePathStyle,
+		}
+	}
+
+	if len(s3.CredentialsSecret) == 0 {
+		return &result, nil
+	}
+	data, err := n.platformService.GetSecretData(instance.Namespace, s3.CredentialsSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get Secret %v", s3.CredentialsSecret)
+	}
+	for _, key := range []string{nexusDefaultSpec.S3CredentialsSecretAccessKeyIdKey, nexusDefaultSpec.S3CredentialsSecretSecretAccessKeyKey} {
+		if _, ok := data[key]; !ok {
+			return nil, fmt.Errorf("key %v is not found in Secret %v", key, s3.CredentialsSecret)
+		}
+	}
+	security := nexus.S3BlobStoreBucketSecurity{
+		AccessKeyId:     string(data[nexusDefaultSpec.S3CredentialsSecretAccessKeyIdKey]),
+		SecretAccessKey: string(data[nexusDefaultSpec.S3CredentialsSecretSecretAccessKeyKey]),
+		SessionToken:    string(data[nexusDefaultSpec.S3CredentialsSecretSessionTokenKey]),
+	}
+	result.BucketConfiguration.BucketSecurity = &security
+	return &result, nil
+}
+
+// getS3BlobStoreScriptParameters returns parameters of create-blobstore script for S3 blob store
+func getS3BlobStoreScriptParameters(blobStore nexus.S3BlobStore) map[string]interface{} {
+	bucket := blobStore.BucketConfiguration.Bucket
+	parameters := map[string]interface{}{
+		"name":       blobStore.Name,
+		"type":       "s3",
+		"bucket":     bucket.Name,
+		"region":     bucket.Region,
+		"prefix":     bucket.Prefix,
+		"expiration": strconv.Itoa(int(bucket.Expiration)),
+	}
+	if security := blobStore.BucketConfiguration.BucketSecurity; security != nil {
+		parameters["access_key_id"] = security.AccessKeyId
+		parameters["secret_access_key"] = security.SecretAccessKey
+		parameters["session_token"] = security.SessionToken
+	}
+	if connection := blobStore.BucketConfiguration.AdvancedBucketConnection; connection != nil {
+		parameters["endpoint"] = connection.Endpoint
+		parameters["force_path_style"] = strconv.FormatBool(connection.ForcePathStyle)
+	}
+	return parameters
+}
+
+// getS3CredentialsHash returns hash of credentials of S3 blob store, empty string is returned if they are not set
+func getS3CredentialsHash(blobStore nexus.S3BlobStore) (string, error) {
+	security := blobStore.BucketConfiguration.BucketSecurity
+	if security == nil {
+		return "", nil
+	}
+	content, err := json.Marshal(security)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal S3 credentials")
+	}
+	return getScriptHash(string(content)), nil
+}
+
+// isS3BlobStoreChanged checks whether bucket, connection settings or soft quota of existing S3 blob store differ
+// from the desired ones. Credentials are not compared since REST API doesn't return them
+func isS3BlobStoreChanged(existing nexus.S3BlobStore, desired nexus.S3BlobStore) bool {
+	if !reflect.DeepEqual(existing.SoftQuota, desired.SoftQuota) ||
+		existing.BucketConfiguration.Bucket != desired.BucketConfiguration.Bucket {
+		return true
+	}
+	existingConnection := nexus.S3BlobStoreAdvancedBucketConnection{}
+	if existing.BucketConfiguration.AdvancedBucketConnection != nil {
+		existingConnection = *existing.BucketConfiguration.AdvancedBucketConnection
+	}
+	desiredConnection := nexus.S3BlobStoreAdvancedBucketConnection{}
+	if desired.BucketConfiguration.AdvancedBucketConnection != nil {
+		desiredConnection = *desired.BucketConfiguration.AdvancedBucketConnection
+	}
+	return existingConnection != desiredConnection
+}
+
+// setupS3BlobStore creates S3 blob store or updates existing one if its configuration or credentials have been changed
+func (n NexusServiceImpl) setupS3BlobStore(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient, blobStore nexus.S3BlobStore, exists bool) error {
+	hash, err := getS3CredentialsHash(blobStore)
+	if err != nil {
+		return err
+	}
+	hashes := getTrackedHashes(*instance, s3CredentialsAnnotationSuffix)
+	if !exists {
+		err = nexusClient.CreateS3BlobStore(blobStore)
+	} else {
+		existing, getErr := nexusClient.GetS3BlobStore(blobStore.Name)
+		if getErr != nil {
+			return getErr
+		}
+		if !isS3BlobStoreChanged(*existing, blobStore) && hashes[blobStore.Name] == hash {
+			return nil
+		}
+		err = nexusClient.UpdateS3BlobStore(blobStore)
+	}
+	if err != nil {
+		return err
+	}
+	hashes[blobStore.Name] = hash
+	return n.setTrackedValue(instance, s3CredentialsAnnotationSuffix, hashes)
+}
+
+// setupBlobStore creates blob store from spec or updates existing one. Type of existing blob store can not be changed.
+// Soft quota is set only through REST API since script is used for Nexus versions which don't support quotas
+func (n NexusServiceImpl) setupBlobStore(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient, blobStore v1alpha1.NexusBlobStore) error {
+	if err := validateBlobStore(blobStore); err != nil {
+		return err
+	}
+	blobStoreType := getBlobStoreType(blobStore)
+
+	existing, err := nexusClient.GetBlobStore(blobStore.Name)
+	if err == nil && existing != nil && !strings.EqualFold(existing.Type, string(blobStoreType)) {
+		return fmt.Errorf("blob store of %v type already exists, its type can not be changed", existing.Type)
+	}
+
+	if blobStoreType == v1alpha1.BlobStoreTypeFile {
+		path := blobStore.Path
+		if len(path) == 0 {
+			path = blobStore.Name
+		}
+		fileBlobStore := nexus.FileBlobStore{Name: blobStore.Name, Path: path, SoftQuota: getBlobStoreSoftQuota(blobStore)}
+		if err == nil {
+			if existing == nil {
+				err = nexusClient.CreateFileBlobStore(fileBlobStore)
+			} else if !reflect.DeepEqual(existing.SoftQuota, fileBlobStore.SoftQuota) {
+				err = nexusClient.UpdateFileBlobStore(fileBlobStore)
+			}
+		}
+		return runScriptIfNotSupported(nexusClient, nexus.BlobStoresApi, err, "create-blobstore", map[string]interface{}{
+			"name": blobStore.Name,
+			"type": "file",
+			"path": path,
+		})
+	}
+
+	s3, getErr := n.getS3BlobStore(*instance, blobStore)
+	if getErr != nil {
+		return getErr
+	}
+	if err == nil {
+		err = n.setupS3BlobStore(instance, nexusClient, *s3, existing != nil)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.BlobStoresApi, err, "create-blobstore", getS3BlobStoreScriptParameters(*s3))
+}
+
+// GetBlobStoresUsage returns usage of all blob stores of Nexus, soft quotas are checked for blob stores which have them
+func (n NexusServiceImpl) GetBlobStoresUsage(instance v1alpha1.Nexus) ([]v1alpha1.NexusBlobStoreStatus, error) {
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return nil, err
+	}
+	blobStores, err := nexusClient.GetBlobStores()
+	if err != nil {
+		// Blob stores API is missing in old Nexus versions, usage is not reported for them
+		if nexus.IsErrNotSupported(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get blob stores")
+	}
+
+	var usage []v1alpha1.NexusBlobStoreStatus
+	for _, b := range blobStores {
+		status := v1alpha1.NexusBlobStoreStatus{
+			Name:                  b.Name,
+			Type:                  b.Type,
+			BlobCount:             b.BlobCount,
+			TotalSizeInBytes:      b.TotalSizeInBytes,
+			AvailableSpaceInBytes: b.AvailableSpaceInBytes,
+		}
+		if b.SoftQuota != nil {
+			quotaStatus, err := nexusClient.GetBlobStoreQuotaStatus(b.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to check soft quota of blob store %v", b.Name)
+			}
+			status.SoftQuota = &v1alpha1.NexusBlobStoreSoftQuotaStatus{
+				Type:         b.SoftQuota.Type,
+				LimitInBytes: b.SoftQuota.Limit * bytesInMegabyte,
+				Violated:     quotaStatus.IsViolation,
+				Message:      quotaStatus.Message,
+			}
+		}
+		usage = append(usage, status)
+	}
+	return usage, nil
+}
@@ -0,0 +1,111 @@
+package nexus
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"testing"
+)
+
+func TestValidateBlobStore(t *testing.T) {
+	quota := func(quotaType string, limit string) *v1alpha1.NexusBlobStoreSoftQuota {
+		return &v1alpha1.NexusBlobStoreSoftQuota{Type: quotaType, Limit: resource.MustParse(limit)}
+	}
+	tests := []struct {
+		name      string
+		blobStore v1alpha1.NexusBlobStore
+		wantErr   bool
+	}{
+		{name: "file by default", blobStore: v1alpha1.NexusBlobStore{Name: "default", Path: "default"}},
+		{name: "s3", blobStore: v1alpha1.NexusBlobStore{Name: "s3", Type: v1alpha1.BlobStoreTypeS3,
+			S3: &v1alpha1.NexusS3BlobStore{Bucket: "nexus"}}},
+		{name: "soft quota", blobStore: v1alpha1.NexusBlobStore{Name: "default",
+			SoftQuota: quota(nexusDefaultSpec.SoftQuotaTypeSpaceRemaining, "10G")}},
+		{name: "s3 settings of file", blobStore: v1alpha1.NexusBlobStore{Name: "default",
+			S3: &v1alpha1.NexusS3BlobStore{Bucket: "nexus"}}, wantErr: true},
+		{name: "s3 without bucket", blobStore: v1alpha1.NexusBlobStore{Name: "s3", Type: v1alpha1.BlobStoreTypeS3,
+			S3: &v1alpha1.NexusS3BlobStore{}}, wantErr: true},
+		{name: "s3 with path", blobStore: v1alpha1.NexusBlobStore{Name: "s3", Type: v1alpha1.BlobStoreTypeS3, Path: "s3",
+			S3: &v1alpha1.NexusS3BlobStore{Bucket: "nexus"}}, wantErr: true},
+		{name: "unsupported type", blobStore: v1alpha1.NexusBlobStore{Name: "azure", Type: "Azure"}, wantErr: true},
+		{name: "unsupported soft quota type", blobStore: v1alpha1.NexusBlobStore{Name: "default",
+			SoftQuota: quota("spaceQuota", "10G")}, wantErr: true},
+		{name: "soft quota below 1M", blobStore: v1alpha1.NexusBlobStore{Name: "default",
+			SoftQuota: quota(nexusDefaultSpec.SoftQuotaTypeSpaceUsed, "999k")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBlobStore(tt.blobStore)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBlobStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsS3BlobStoreChanged(t *testing.T) {
+	blobStore := func(modify func(*nexus.S3BlobStore)) nexus.S3BlobStore {
+		result := nexus.S3BlobStore{
+			Name: "s3",
+			BucketConfiguration: nexus.S3BlobStoreBucketConfiguration{
+				Bucket: nexus.S3BlobStoreBucket{Region: "us-east-1", Name: "nexus", Expiration: 3},
+			},
+		}
+		if modify != nil {
+			modify(&result)
+		}
+		return result
+	}
+	tests := []struct {
+		name     string
+		existing nexus.S3BlobStore
+		desired  nexus.S3BlobStore
+		want     bool
+	}{
+		{name: "same", existing: blobStore(nil), desired: blobStore(nil)},
+		{
+			name:     "credentials are not compared",
+			existing: blobStore(nil),
+			desired: blobStore(func(b *nexus.S3BlobStore) {
+				b.BucketConfiguration.BucketSecurity = &nexus.S3BlobStoreBucketSecurity{AccessKeyId: "id", SecretAccessKey: "key"}
+			}),
+		},
+		{
+			name: "empty advanced connection equals to absent one",
+			existing: blobStore(func(b *nexus.S3BlobStore) {
+				b.BucketConfiguration.AdvancedBucketConnection = &nexus.S3BlobStoreAdvancedBucketConnection{}
+			}),
+			desired: blobStore(nil),
+		},
+		{
+			name:     "changed bucket prefix",
+			existing: blobStore(nil),
+			desired:  blobStore(func(b *nexus.S3BlobStore) { b.BucketConfiguration.Bucket.Prefix = "nexus" }),
+			want:     true,
+		},
+		{
+			name:     "added soft quota",
+			existing: blobStore(nil),
+			desired: blobStore(func(b *nexus.S3BlobStore) {
+				b.SoftQuota = &nexus.BlobStoreSoftQuota{Type: nexusDefaultSpec.SoftQuotaTypeSpaceUsed, Limit: 1000}
+			}),
+			want: true,
+		},
+		{
+			name:     "changed endpoint",
+			existing: blobStore(nil),
+			desired: blobStore(func(b *nexus.S3BlobStore) {
+				b.BucketConfiguration.AdvancedBucketConnection = &nexus.S3BlobStoreAdvancedBucketConnection{Endpoint: "https://minio:9000"}
+			}),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isS3BlobStoreChanged(tt.existing, tt.desired); got != tt.want {
+				t.Errorf("isS3BlobStoreChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
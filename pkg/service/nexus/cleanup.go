@@ -0,0 +1,58 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+)
+
+// Cleanup removes resources which are created for Nexus but are not owned by Nexus CR and retains volumes
+// if it is requested by retention policy
+func (n NexusServiceImpl) Cleanup(instance v1alpha1.Nexus) error {
+	if err := n.platformService.DeleteSecurityContext(instance); err != nil {
+		return errors.Wrap(err, "failed to delete Security Context Constraint")
+	}
+
+	if err := n.deleteJenkinsServiceAccounts(instance); err != nil {
+		return err
+	}
+
+	// Keycloak client is deleted even if the integration has been disabled since it could be created before
+	if err := n.platformService.DeleteKeycloakClient(instance.Name, instance.Namespace); err != nil {
+		return err
+	}
+
+	if instance.Spec.VolumeRetentionPolicy == v1alpha1.VolumeRetentionPolicyRetain {
+		if err := n.platformService.RetainVolumes(instance); err != nil {
+			return errors.Wrap(err, "failed to retain volumes")
+		}
+	}
+	return nil
+}
+
+// deleteJenkinsServiceAccounts deletes Jenkins service accounts created for users from default users Config Map
+func (n NexusServiceImpl) deleteJenkinsServiceAccounts(instance v1alpha1.Nexus) error {
+	nexusDefaultUsers, err := n.platformService.GetConfigMapData(instance.Namespace, fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusDefaultUsersConfigMapPrefix))
+	if err != nil {
+		return errors.Wrap(err, "failed to get default users from Config Map")
+	}
+	users, ok := nexusDefaultUsers[nexusDefaultSpec.NexusDefaultUsersConfigMapPrefix]
+	if !ok {
+		return nil
+	}
+
+	var parsedUsers []map[string]interface{}
+	if err := json.Unmarshal([]byte(users), &parsedUsers); err != nil {
+		return errors.Wrap(err, "failed to parse default users")
+	}
+
+	for _, userProperties := range parsedUsers {
+		name := fmt.Sprintf("%s-%s", instance.Name, getStringParameter(userProperties, "username"))
+		if err := n.platformService.DeleteJenkinsServiceAccount(instance.Namespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package nexus
+
+import (
+	"errors"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+)
+
+// cleanupPlatform records deletions requested by cleanup, other methods of platform service are not implemented
+type cleanupPlatform struct {
+	platform.PlatformService
+	configMaps map[string]map[string]string
+	err        error
+	calls      []string
+}
+
+func (p *cleanupPlatform) DeleteSecurityContext(instance v1alpha1.Nexus) error {
+	p.calls = append(p.calls, "DeleteSecurityContext")
+	return p.err
+}
+
+func (p *cleanupPlatform) GetConfigMapData(namespace string, name string) (map[string]string, error) {
+	return p.configMaps[name], nil
+}
+
+func (p *cleanupPlatform) DeleteJenkinsServiceAccount(namespace string, name string) error {
+	p.calls = append(p.calls, fmt.Sprintf("DeleteJenkinsServiceAccount %v", name))
+	return nil
+}
+
+func (p *cleanupPlatform) DeleteKeycloakClient(name string, namespace string) error {
+	p.calls = append(p.calls, fmt.Sprintf("DeleteKeycloakClient %v", name))
+	return nil
+}
+
+func (p *cleanupPlatform) RetainVolumes(instance v1alpha1.Nexus) error {
+	p.calls = append(p.calls, "RetainVolumes")
+	return nil
+}
+
+func TestCleanup(t *testing.T) {
+	defaultUsers := map[string]map[string]string{
+		"nexus-default-users": {"default-users": `[{"username": "ci.user"}, {"username": "jenkins"}]`},
+	}
+	tests := []struct {
+		name       string
+		spec       v1alpha1.NexusSpec
+		configMaps map[string]map[string]string
+		err        error
+		wantCalls  []string
+		wantErr    bool
+	}{
+		{
+			name:      "volumes are deleted by default",
+			wantCalls: []string{"DeleteSecurityContext", "DeleteKeycloakClient nexus"},
+		},
+		{
+			name:      "Keycloak client is deleted if Keycloak integration is enabled",
+			spec:      v1alpha1.NexusSpec{KeycloakSpec: v1alpha1.KeycloakSpec{Enabled: true}},
+			wantCalls: []string{"DeleteSecurityContext", "DeleteKeycloakClient nexus"},
+		},
+		{
+			name:       "service accounts of default users are deleted",
+			configMaps: defaultUsers,
+			wantCalls: []string{"DeleteSecurityContext", "DeleteJenkinsServiceAccount nexus-ci.user",
+				"DeleteJenkinsServiceAccount nexus-jenkins", "DeleteKeycloakClient nexus"},
+		},
+		{
+			name:      "volumes are retained",
+			spec:      v1alpha1.NexusSpec{VolumeRetentionPolicy: v1alpha1.VolumeRetentionPolicyRetain},
+			wantCalls: []string{"DeleteSecurityContext", "DeleteKeycloakClient nexus", "RetainVolumes"},
+		},
+		{
+			name:      "failed deletion stops cleanup",
+			spec:      v1alpha1.NexusSpec{VolumeRetentionPolicy: v1alpha1.VolumeRetentionPolicyRetain},
+			err:       errors.New("forbidden"),
+			wantCalls: []string{"DeleteSecurityContext"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platformService := &cleanupPlatform{configMaps: tt.configMaps, err: tt.err}
+			instance := v1alpha1.Nexus{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: "edp"}, Spec: tt.spec}
+			err := NexusServiceImpl{platformService: platformService}.Cleanup(instance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Cleanup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(platformService.calls, tt.wantCalls) {
+				t.Errorf("Cleanup() calls = %v, want %v", platformService.calls, tt.wantCalls)
+			}
+		})
+	}
+}
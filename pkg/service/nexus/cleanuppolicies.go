@@ -0,0 +1,137 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"reflect"
+	"strconv"
+)
+
+// cleanupPoliciesAnnotationSuffix - annotation on Nexus CR which keeps names of cleanup policies created by operator
+const cleanupPoliciesAnnotationSuffix = "cleanup-policies"
+
+// cleanupPolicyFormats maps repository formats to the formats of cleanup policies which differ from them
+var cleanupPolicyFormats = map[string]string{
+	"maven": "maven2",
+}
+
+// validateCleanupPolicy checks that cleanup policy has at least one criterion and the criteria are valid
+func validateCleanupPolicy(policy v1alpha1.NexusCleanupPolicy) error {
+	if len(policy.Name) == 0 {
+		return errors.New("name of cleanup policy is not set")
+	}
+	if policy.LastDownloadedDays < 0 || policy.LastBlobUpdatedDays < 0 {
+		return errors.New("number of days in criteria must be positive")
+	}
+	if policy.ReleaseType != "" && policy.ReleaseType != "RELEASES" && policy.ReleaseType != "PRERELEASES" {
+		return fmt.Errorf("release type %v is not supported", policy.ReleaseType)
+	}
+	if policy.LastDownloadedDays == 0 && policy.LastBlobUpdatedDays == 0 && len(policy.AssetNameRegex) == 0 &&
+		len(policy.ReleaseType) == 0 {
+		return errors.New("at least one criterion must be set")
+	}
+	return nil
+}
+
+// newCleanupPolicyApiModel converts cleanup policy from spec to the model of cleanup policies REST API
+func newCleanupPolicyApiModel(policy v1alpha1.NexusCleanupPolicy) nexus.CleanupPolicy {
+	format := policy.Format
+	if f, ok := cleanupPolicyFormats[format]; ok {
+		format = f
+	}
+	if len(format) == 0 {
+		format = nexusDefaultSpec.CleanupPolicyAllFormats
+	}
+	result := nexus.CleanupPolicy{
+		Name:                policy.Name,
+		Notes:               policy.Notes,
+		Format:              format,
+		CriteriaReleaseType: policy.ReleaseType,
+		CriteriaAssetRegex:  policy.AssetNameRegex,
+	}
+	if policy.LastBlobUpdatedDays != 0 {
+		result.CriteriaLastBlobUpdated = &policy.LastBlobUpdatedDays
+	}
+	if policy.LastDownloadedDays != 0 {
+		result.CriteriaLastDownloaded = &policy.LastDownloadedDays
+	}
+	return result
+}
+
+// getCleanupPolicyScriptParameters returns parameters of setup-cleanup-policy script for cleanup policy
+func getCleanupPolicyScriptParameters(policy nexus.CleanupPolicy) map[string]interface{} {
+	parameters := map[string]interface{}{
+		"name":         policy.Name,
+		"notes":        policy.Notes,
+		"format":       policy.Format,
+		"release_type": policy.CriteriaReleaseType,
+		"asset_regex":  policy.CriteriaAssetRegex,
+	}
+	if policy.CriteriaLastBlobUpdated != nil {
+		parameters["last_blob_updated"] = strconv.Itoa(int(*policy.CriteriaLastBlobUpdated))
+	}
+	if policy.CriteriaLastDownloaded != nil {
+		parameters["last_downloaded"] = strconv.Itoa(int(*policy.CriteriaLastDownloaded))
+	}
+	return parameters
+}
+
+// setupCleanupPolicy creates cleanup policy or updates existing one
+func (n NexusServiceImpl) setupCleanupPolicy(nexusClient *nexus.NexusClient, policy nexus.CleanupPolicy) error {
+	existing, err := nexusClient.GetCleanupPolicy(policy.Name)
+	if err == nil {
+		if existing == nil {
+			err = nexusClient.CreateCleanupPolicy(policy)
+		} else if !reflect.DeepEqual(*existing, policy) {
+			err = nexusClient.UpdateCleanupPolicy(policy)
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.CleanupPoliciesApi, err, "setup-cleanup-policy", getCleanupPolicyScriptParameters(policy))
+}
+
+// deleteCleanupPolicy deletes cleanup policy, policy which doesn't exist is ignored
+func (n NexusServiceImpl) deleteCleanupPolicy(nexusClient *nexus.NexusClient, name string) error {
+	existing, err := nexusClient.GetCleanupPolicy(name)
+	if err == nil {
+		if existing == nil {
+			return nil
+		}
+		err = nexusClient.DeleteCleanupPolicy(name)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.CleanupPoliciesApi, err, "delete-cleanup-policy", map[string]interface{}{"name": name})
+}
+
+// syncCleanupPolicies creates or updates cleanup policies from spec and deletes the ones which have been created by
+// operator but removed from spec. Policies are applied by Cleanup service task which is declared with other tasks
+func (n NexusServiceImpl) syncCleanupPolicies(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient) error {
+	created := getCreatedNames(*instance, cleanupPoliciesAnnotationSuffix)
+	var declared []string
+	for _, policy := range instance.Spec.CleanupPolicies {
+		if err := validateCleanupPolicy(policy); err != nil {
+			return errors.Wrapf(err, "cleanup policy %v is invalid", policy.Name)
+		}
+		if err := n.setupCleanupPolicy(nexusClient, newCleanupPolicyApiModel(policy)); err != nil {
+			return errors.Wrapf(err, "failed to set up cleanup policy %v", policy.Name)
+		}
+		declared = append(declared, policy.Name)
+	}
+
+	for _, name := range created {
+		if controllerHelper.ContainsString(declared, name) {
+			continue
+		}
+		if err := n.deleteCleanupPolicy(nexusClient, name); err != nil {
+			return errors.Wrapf(err, "failed to delete cleanup policy %v", name)
+		}
+		log.Info("Cleanup policy has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "Policy", name)
+	}
+
+	if reflect.DeepEqual(created, declared) {
+		return nil
+	}
+	return n.setTrackedValue(instance, cleanupPoliciesAnnotationSuffix, declared)
+}
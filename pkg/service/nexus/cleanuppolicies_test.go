@@ -0,0 +1,66 @@
+package nexus
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCleanupPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  v1alpha1.NexusCleanupPolicy
+		wantErr bool
+	}{
+		{name: "last downloaded", policy: v1alpha1.NexusCleanupPolicy{Name: "unused", LastDownloadedDays: 90}},
+		{name: "prereleases", policy: v1alpha1.NexusCleanupPolicy{Name: "snapshots", Format: "maven", ReleaseType: "PRERELEASES"}},
+		{name: "asset name regex", policy: v1alpha1.NexusCleanupPolicy{Name: "tmp", AssetNameRegex: ".*/tmp/.*"}},
+		{name: "name is not set", policy: v1alpha1.NexusCleanupPolicy{LastDownloadedDays: 90}, wantErr: true},
+		{name: "negative days", policy: v1alpha1.NexusCleanupPolicy{Name: "old", LastBlobUpdatedDays: -1}, wantErr: true},
+		{name: "unsupported release type", policy: v1alpha1.NexusCleanupPolicy{Name: "old", ReleaseType: "SNAPSHOTS"}, wantErr: true},
+		{name: "no criteria", policy: v1alpha1.NexusCleanupPolicy{Name: "all", Format: "npm"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCleanupPolicy(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCleanupPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewCleanupPolicyApiModel(t *testing.T) {
+	days := int32(30)
+	tests := []struct {
+		name   string
+		policy v1alpha1.NexusCleanupPolicy
+		want   nexus.CleanupPolicy
+	}{
+		{
+			name:   "all formats by default",
+			policy: v1alpha1.NexusCleanupPolicy{Name: "unused", LastDownloadedDays: days},
+			want:   nexus.CleanupPolicy{Name: "unused", Format: nexusDefaultSpec.CleanupPolicyAllFormats, CriteriaLastDownloaded: &days},
+		},
+		{
+			name:   "maven format is renamed",
+			policy: v1alpha1.NexusCleanupPolicy{Name: "snapshots", Notes: "old snapshots", Format: "maven", ReleaseType: "PRERELEASES", LastBlobUpdatedDays: days},
+			want: nexus.CleanupPolicy{Name: "snapshots", Notes: "old snapshots", Format: "maven2", CriteriaReleaseType: "PRERELEASES",
+				CriteriaLastBlobUpdated: &days},
+		},
+		{
+			name:   "npm format",
+			policy: v1alpha1.NexusCleanupPolicy{Name: "tmp", Format: "npm", AssetNameRegex: ".*/tmp/.*"},
+			want:   nexus.CleanupPolicy{Name: "tmp", Format: "npm", CriteriaAssetRegex: ".*/tmp/.*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newCleanupPolicyApiModel(tt.policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newCleanupPolicyApiModel() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
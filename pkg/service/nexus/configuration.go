@@ -0,0 +1,298 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// runScriptIfNotSupported runs Groovy script when REST API with the given collection endpoint is not available
+// in the running Nexus version. Error of available API (e.g. 404 of absent item) is returned as is
+func runScriptIfNotSupported(nexusClient *nexus.NexusClient, api string, err error, scriptName string, parameters map[string]interface{}) error {
+	if err == nil || !nexus.IsErrNotSupported(err) {
+		return err
+	}
+	supported, checkErr := nexusClient.IsApiSupported(api)
+	if checkErr != nil {
+		return errors.Wrapf(checkErr, "failed to check if %v REST API is supported", api)
+	}
+	if supported {
+		return err
+	}
+	return runFallbackScript(nexusClient, api, scriptName, parameters)
+}
+
+// runFallbackScript runs Groovy script instead of REST API which is not available in the running Nexus version
+func runFallbackScript(nexusClient *nexus.NexusClient, api string, scriptName string, parameters map[string]interface{}) error {
+	log.V(1).Info("REST API is not supported, falling back to script", "Api", api, "Script", scriptName)
+	if _, err := nexusClient.RunScript(scriptName, parameters); err != nil {
+		return errors.Wrapf(err, "%v REST API is not supported by running Nexus version and script %v failed, "+
+			"scripting has to be enabled for this version", api, scriptName)
+	}
+	return nil
+}
+
+func getStringParameter(parameters map[string]interface{}, key string) string {
+	value, ok := parameters[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func getStringSliceParameter(parameters map[string]interface{}, key string) []string {
+	switch value := parameters[key].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		out := make([]string, 0, len(value))
+		for _, v := range value {
+			out = append(out, fmt.Sprint(v))
+		}
+		return out
+	}
+	return []string{}
+}
+
+func (n NexusServiceImpl) changeAdminPassword(nexusClient *nexus.NexusClient, password string) error {
+	err := nexusClient.ChangeUserPassword(nexusDefaultSpec.NexusDefaultAdminUser, password)
+	return runScriptIfNotSupported(nexusClient, nexus.UsersApi, err, "update-admin-password", map[string]interface{}{"new_password": password})
+}
+
+func (n NexusServiceImpl) disableOutreachCapability(nexusClient *nexus.NexusClient) error {
+	capabilities, err := nexusClient.GetCapabilities()
+	if err == nil {
+		for _, c := range capabilities {
+			if !strings.HasPrefix(c.Type, "Outreach") || !c.Enabled {
+				continue
+			}
+			c.Enabled = false
+			if err = nexusClient.UpdateCapability(c); err != nil {
+				break
+			}
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.CapabilitiesApi, err, "disable-outreach-capability", nil)
+}
+
+func (n NexusServiceImpl) setupCapability(nexusClient *nexus.NexusClient, parameters map[string]interface{}) error {
+	capabilityType := getStringParameter(parameters, "capability_typeId")
+	properties := map[string]string{}
+	if p, ok := parameters["capability_properties"].(map[string]interface{}); ok {
+		for k := range p {
+			properties[k] = getStringParameter(p, k)
+		}
+	}
+
+	existing, err := nexusClient.GetCapabilityByType(capabilityType)
+	if err == nil {
+		if existing == nil {
+			err = nexusClient.CreateCapability(nexus.Capability{
+				Type:       capabilityType,
+				Notes:      "configured through api",
+				Enabled:    true,
+				Properties: properties,
+			})
+		} else {
+			existing.Properties = properties
+			err = nexusClient.UpdateCapability(*existing)
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.CapabilitiesApi, err, "setup-capability", parameters)
+}
+
+func (n NexusServiceImpl) enableRealm(nexusClient *nexus.NexusClient, realm string) error {
+	activeRealms, err := nexusClient.GetActiveRealms()
+	if err == nil && !controllerHelper.ContainsString(activeRealms, realm) {
+		err = nexusClient.SetActiveRealms(append(activeRealms, realm))
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.RealmsApi, err, "enable-realm", map[string]interface{}{"name": realm})
+}
+
+// validateRealms checks that realms from spec include the realms required by LDAP server and Keycloak integration,
+// otherwise they would be configured but never used since realms which are not listed are deactivated
+func validateRealms(instance v1alpha1.Nexus) error {
+	if len(instance.Spec.Realms) == 0 {
+		return nil
+	}
+	if instance.Spec.Ldap != nil && !controllerHelper.ContainsString(instance.Spec.Realms, nexusDefaultSpec.NexusLdapRealm) {
+		return fmt.Errorf("%v realm has to be listed in realms of Nexus %v", nexusDefaultSpec.NexusLdapRealm, instance.Name)
+	}
+	if instance.Spec.KeycloakSpec.Enabled && !controllerHelper.ContainsString(instance.Spec.Realms, nexusDefaultSpec.NexusRutAuthRealm) {
+		return fmt.Errorf("%v realm has to be listed in realms of Nexus %v since Keycloak integration is enabled",
+			nexusDefaultSpec.NexusRutAuthRealm, instance.Name)
+	}
+	return nil
+}
+
+// setupRealms activates realms in the given order and deactivates the rest of realms.
+// NexusAuthenticatingRealm is activated first if it is not listed, otherwise operator would lose access to Nexus
+func (n NexusServiceImpl) setupRealms(nexusClient *nexus.NexusClient, realms []string) error {
+	if !controllerHelper.ContainsString(realms, nexusDefaultSpec.NexusAuthenticatingRealm) {
+		realms = append([]string{nexusDefaultSpec.NexusAuthenticatingRealm}, realms...)
+	}
+
+	available, err := nexusClient.GetAvailableRealms()
+	if err != nil {
+		if nexus.IsErrNotSupported(err) {
+			// Script only enables realms, so realms which are not listed would stay active and the order is not applied
+			return errors.New("realms REST API is not supported by running Nexus version, realms can't be set, " +
+				"remove them from spec to enable the required realms only")
+		}
+		return err
+	}
+
+	var availableIds []string
+	for _, r := range available {
+		availableIds = append(availableIds, r.Id)
+	}
+	for _, realm := range realms {
+		if !controllerHelper.ContainsString(availableIds, realm) {
+			return fmt.Errorf("realm %v is not available, available realms are %v", realm, strings.Join(availableIds, ", "))
+		}
+	}
+
+	active, err := nexusClient.GetActiveRealms()
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(active, realms) {
+		return nil
+	}
+	log.Info("Updating active realms", "Realms", realms)
+	return nexusClient.SetActiveRealms(realms)
+}
+
+// setupAnonymousAccess enables or disables anonymous access with the given anonymous user
+func (n NexusServiceImpl) setupAnonymousAccess(nexusClient *nexus.NexusClient, access v1alpha1.NexusAnonymousAccess) error {
+	desired := nexus.AnonymousAccess{
+		Enabled:   access.Enabled,
+		UserId:    access.UserId,
+		RealmName: access.RealmName,
+	}
+	if len(desired.UserId) == 0 {
+		desired.UserId = nexusDefaultSpec.NexusDefaultAnonymousUser
+	}
+	if len(desired.RealmName) == 0 {
+		desired.RealmName = nexusDefaultSpec.NexusAuthorizingRealm
+	}
+
+	existing, err := nexusClient.GetAnonymousAccess()
+	if err == nil && *existing != desired {
+		err = nexusClient.SetAnonymousAccess(desired)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.AnonymousAccessApi, err, "setup-anonymous-access", map[string]interface{}{
+		"anonymous_access": desired.Enabled,
+		"user_id":          desired.UserId,
+		"realm_name":       desired.RealmName,
+	})
+}
+
+// setBaseUrl sets base URL which is used by Nexus in generated links
+func (n NexusServiceImpl) setBaseUrl(nexusClient *nexus.NexusClient, baseUrl string) error {
+	existing, err := nexusClient.GetCapabilityByType(nexusDefaultSpec.NexusBaseUrlCapability)
+	if err == nil {
+		if existing == nil {
+			err = nexusClient.CreateCapability(nexus.Capability{
+				Type:       nexusDefaultSpec.NexusBaseUrlCapability,
+				Notes:      "configured through api",
+				Enabled:    true,
+				Properties: map[string]string{"url": baseUrl},
+			})
+		} else if !existing.Enabled || existing.Properties["url"] != baseUrl {
+			existing.Enabled = true
+			existing.Properties = map[string]string{"url": baseUrl}
+			err = nexusClient.UpdateCapability(*existing)
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.CapabilitiesApi, err, "setup-base-url", map[string]interface{}{"base_url": baseUrl})
+}
+
+// sortedStrings returns sorted copy of the slice, it is used to compare lists which order is not preserved by Nexus
+func sortedStrings(values []string) []string {
+	result := append([]string{}, values...)
+	sort.Strings(result)
+	return result
+}
+
+// setupRole creates role or updates existing one if its attributes differ from parameters of setup-role script
+func (n NexusServiceImpl) setupRole(nexusClient *nexus.NexusClient, parameters map[string]interface{}) error {
+	role := nexus.Role{
+		Id:          getStringParameter(parameters, "id"),
+		Name:        getStringParameter(parameters, "name"),
+		Description: getStringParameter(parameters, "description"),
+		Privileges:  getStringSliceParameter(parameters, "privileges"),
+		Roles:       getStringSliceParameter(parameters, "roles"),
+	}
+
+	existing, err := nexusClient.GetRole(role.Id)
+	if err == nil {
+		if existing == nil {
+			err = nexusClient.CreateRole(role)
+		} else if existing.Name != role.Name || existing.Description != role.Description ||
+			!reflect.DeepEqual(sortedStrings(existing.Privileges), sortedStrings(role.Privileges)) ||
+			!reflect.DeepEqual(sortedStrings(existing.Roles), sortedStrings(role.Roles)) {
+			err = nexusClient.UpdateRole(role)
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.RolesApi, err, "setup-role", parameters)
+}
+
+func (n NexusServiceImpl) createBlobStore(nexusClient *nexus.NexusClient, parameters map[string]interface{}) error {
+	name := getStringParameter(parameters, "name")
+	existing, err := nexusClient.GetBlobStore(name)
+	if err == nil && existing == nil {
+		err = nexusClient.CreateFileBlobStore(nexus.FileBlobStore{Name: name, Path: getStringParameter(parameters, "path")})
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.BlobStoresApi, err, "create-blobstore", parameters)
+}
+
+// setupUser creates user or updates attributes and roles of existing one with parameters of setup-user script.
+// Password is set only when user is created, existing user is enabled but its password is never reset
+func (n NexusServiceImpl) setupUser(nexusClient *nexus.NexusClient, parameters map[string]interface{}) error {
+	username := getStringParameter(parameters, "username")
+	password := getStringParameter(parameters, "password")
+	user := nexus.User{
+		UserId:       username,
+		FirstName:    getStringParameter(parameters, "first_name"),
+		LastName:     getStringParameter(parameters, "last_name"),
+		EmailAddress: getStringParameter(parameters, "email"),
+		Source:       nexus.UserSourceDefault,
+		Status:       nexus.UserStatusActive,
+		Roles:        getStringSliceParameter(parameters, "roles"),
+	}
+	// REST API requires names and email which are optional in the script
+	if len(user.FirstName) == 0 {
+		user.FirstName = username
+	}
+	if len(user.LastName) == 0 {
+		user.LastName = username
+	}
+	if len(user.EmailAddress) == 0 {
+		user.EmailAddress = username
+		if !strings.Contains(username, "@") {
+			user.EmailAddress = fmt.Sprintf("%v@%v", username, nexusDefaultSpec.NexusDefaultUserEmailDomain)
+		}
+	}
+
+	existing, err := nexusClient.GetUser(username)
+	if err == nil {
+		if existing == nil {
+			user.Password = password
+			err = nexusClient.CreateUser(user)
+		} else if existing.FirstName != user.FirstName || existing.LastName != user.LastName ||
+			existing.EmailAddress != user.EmailAddress || existing.Status != user.Status ||
+			!reflect.DeepEqual(sortedStrings(existing.Roles), sortedStrings(user.Roles)) {
+			user.ReadOnly = existing.ReadOnly
+			user.ExternalRoles = existing.ExternalRoles
+			err = nexusClient.UpdateUser(user)
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.UsersApi, err, "setup-user", parameters)
+}
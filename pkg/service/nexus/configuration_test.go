@@ -0,0 +1,272 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// realmServer emulates realms API of Nexus and records lists of realms which have been activated
+type realmServer struct {
+	available []string
+	active    []string
+	updates   [][]string
+}
+
+func (s *realmServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/security/realms/available" && s.available != nil:
+		var realms []nexus.Realm
+		for _, id := range s.available {
+			realms = append(realms, nexus.Realm{Id: id, Name: id})
+		}
+		_ = json.NewEncoder(w).Encode(realms)
+	case r.Method == http.MethodGet && r.URL.Path == "/security/realms/active":
+		_ = json.NewEncoder(w).Encode(s.active)
+	case r.Method == http.MethodPut && r.URL.Path == "/security/realms/active":
+		if err := json.NewDecoder(r.Body).Decode(&s.active); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.updates = append(s.updates, s.active)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSetupRealms(t *testing.T) {
+	available := []string{"NexusAuthenticatingRealm", "LdapRealm", "DockerToken", "rutauth-realm"}
+	tests := []struct {
+		name        string
+		unsupported bool
+		active      []string
+		realms      []string
+		wantUpdates [][]string
+		wantErr     bool
+	}{
+		{
+			name:        "NexusAuthenticatingRealm is activated first if it is not listed",
+			active:      []string{"NexusAuthenticatingRealm"},
+			realms:      []string{"LdapRealm"},
+			wantUpdates: [][]string{{"NexusAuthenticatingRealm", "LdapRealm"}},
+		},
+		{
+			name:        "realms are ordered and not listed realms are deactivated",
+			active:      []string{"NexusAuthenticatingRealm", "LdapRealm", "DockerToken"},
+			realms:      []string{"LdapRealm", "NexusAuthenticatingRealm"},
+			wantUpdates: [][]string{{"LdapRealm", "NexusAuthenticatingRealm"}},
+		},
+		{
+			name:   "active realms are not changed",
+			active: []string{"NexusAuthenticatingRealm", "rutauth-realm"},
+			realms: []string{"NexusAuthenticatingRealm", "rutauth-realm"},
+		},
+		{
+			name:    "realm is not available",
+			active:  []string{"NexusAuthenticatingRealm"},
+			realms:  []string{"SamlRealm"},
+			wantErr: true,
+		},
+		{
+			name:        "realms API is not supported",
+			unsupported: true,
+			active:      []string{"NexusAuthenticatingRealm"},
+			realms:      []string{"NexusAuthenticatingRealm", "rutauth-realm"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &realmServer{available: available, active: tt.active}
+			if tt.unsupported {
+				s.available = nil
+			}
+			server := httptest.NewServer(s)
+			defer server.Close()
+			nexusClient := &nexus.NexusClient{}
+			_ = nexusClient.InitNewRestClient(&v1alpha1.Nexus{}, server.URL, "admin", "admin")
+
+			err := NexusServiceImpl{}.setupRealms(nexusClient, tt.realms)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setupRealms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(s.updates, tt.wantUpdates) {
+				t.Errorf("setupRealms() activated realms = %v, want %v", s.updates, tt.wantUpdates)
+			}
+		})
+	}
+}
+
+func TestValidateRealms(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.NexusSpec
+		wantErr bool
+	}{
+		{name: "realms are not set", spec: v1alpha1.NexusSpec{Ldap: &v1alpha1.NexusLdap{}, KeycloakSpec: v1alpha1.KeycloakSpec{Enabled: true}}},
+		{
+			name: "realms of LDAP and Keycloak are listed",
+			spec: v1alpha1.NexusSpec{Ldap: &v1alpha1.NexusLdap{}, KeycloakSpec: v1alpha1.KeycloakSpec{Enabled: true},
+				Realms: []string{"LdapRealm", "rutauth-realm"}},
+		},
+		{
+			name:    "LdapRealm is not listed",
+			spec:    v1alpha1.NexusSpec{Ldap: &v1alpha1.NexusLdap{}, Realms: []string{"rutauth-realm"}},
+			wantErr: true,
+		},
+		{
+			name:    "rutauth-realm is not listed",
+			spec:    v1alpha1.NexusSpec{KeycloakSpec: v1alpha1.KeycloakSpec{Enabled: true}, Realms: []string{"NexusAuthenticatingRealm"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRealms(v1alpha1.Nexus{Spec: tt.spec}); (err != nil) != tt.wantErr {
+				t.Errorf("validateRealms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// anonymousAccessServer emulates anonymous access API of Nexus and records updates of settings
+type anonymousAccessServer struct {
+	access  nexus.AnonymousAccess
+	updates []nexus.AnonymousAccess
+}
+
+func (s *anonymousAccessServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/security/anonymous":
+		_ = json.NewEncoder(w).Encode(s.access)
+	case r.Method == http.MethodPut && r.URL.Path == "/security/anonymous":
+		if err := json.NewDecoder(r.Body).Decode(&s.access); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.updates = append(s.updates, s.access)
+		_ = json.NewEncoder(w).Encode(s.access)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSetupAnonymousAccess(t *testing.T) {
+	defaultAccess := nexus.AnonymousAccess{Enabled: true, UserId: "anonymous", RealmName: "NexusAuthorizingRealm"}
+	tests := []struct {
+		name        string
+		existing    nexus.AnonymousAccess
+		access      v1alpha1.NexusAnonymousAccess
+		wantUpdates []nexus.AnonymousAccess
+	}{
+		{
+			name:        "disabled with default user",
+			existing:    defaultAccess,
+			wantUpdates: []nexus.AnonymousAccess{{UserId: "anonymous", RealmName: "NexusAuthorizingRealm"}},
+		},
+		{
+			name:     "unchanged settings",
+			existing: defaultAccess,
+			access:   v1alpha1.NexusAnonymousAccess{Enabled: true},
+		},
+		{
+			name:     "user from LDAP",
+			existing: defaultAccess,
+			access:   v1alpha1.NexusAnonymousAccess{Enabled: true, UserId: "guest", RealmName: "LdapRealm"},
+			wantUpdates: []nexus.AnonymousAccess{
+				{Enabled: true, UserId: "guest", RealmName: "LdapRealm"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &anonymousAccessServer{access: tt.existing}
+			server := httptest.NewServer(s)
+			defer server.Close()
+			nexusClient := &nexus.NexusClient{}
+			_ = nexusClient.InitNewRestClient(&v1alpha1.Nexus{}, server.URL, "admin", "admin")
+
+			if err := (NexusServiceImpl{}).setupAnonymousAccess(nexusClient, tt.access); err != nil {
+				t.Fatalf("setupAnonymousAccess() error = %v", err)
+			}
+			if !reflect.DeepEqual(s.updates, tt.wantUpdates) {
+				t.Errorf("setupAnonymousAccess() updates = %v, want %v", s.updates, tt.wantUpdates)
+			}
+		})
+	}
+}
+
+func TestRunScriptIfNotSupported(t *testing.T) {
+	notFound := nexus.NexusApiError{Method: http.MethodPut, Path: "/security/roles/edp-admin", StatusCode: http.StatusNotFound}
+	tests := []struct {
+		name         string
+		rolesApi     bool
+		scripting    bool
+		err          error
+		wantRequests []string
+		wantErr      bool
+	}{
+		{name: "successful request", rolesApi: true},
+		{
+			name:     "other error is returned",
+			rolesApi: true,
+			err:      nexus.NexusApiError{Method: http.MethodPut, Path: "/security/roles/edp-admin", StatusCode: http.StatusBadRequest},
+			wantErr:  true,
+		},
+		{
+			name:         "absent item of supported API is not replaced by script",
+			rolesApi:     true,
+			err:          notFound,
+			wantRequests: []string{"GET /security/roles"},
+			wantErr:      true,
+		},
+		{
+			name:         "script is run if API is not supported",
+			scripting:    true,
+			err:          notFound,
+			wantRequests: []string{"GET /security/roles", "POST /script/setup-role/run", "POST /script/setup-role/run"},
+		},
+		{
+			name:         "API is not supported and scripting is disabled",
+			err:          notFound,
+			wantRequests: []string{"GET /security/roles", "POST /script/setup-role/run", "POST /script/setup-role/run"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%v %v", r.Method, r.URL.Path))
+				switch {
+				case r.URL.Path == "/security/roles" && tt.rolesApi:
+					_, _ = w.Write([]byte("[]"))
+				case r.URL.Path == "/script/setup-role/run" && tt.scripting:
+					_, _ = w.Write([]byte(`{"result":"ok"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			nexusClient := &nexus.NexusClient{}
+			_ = nexusClient.InitNewRestClient(&v1alpha1.Nexus{}, server.URL, "admin", "admin")
+
+			// API is listed only on the first call, support of API is decided once per client
+			for i := 0; i < 2; i++ {
+				err := runScriptIfNotSupported(nexusClient, nexus.RolesApi, tt.err, "setup-role", nil)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("runScriptIfNotSupported() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("runScriptIfNotSupported() requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package nexus
+
+import (
+	"crypto/tls"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ldapScriptAuthSchemes maps authentication schemes of REST API to the ones of setup-ldap script
+var ldapScriptAuthSchemes = map[string]string{
+	"NONE":       "none",
+	"SIMPLE":     "simple",
+	"DIGEST_MD5": "DIGEST-MD5",
+	"CRAM_MD5":   "CRAM-MD5",
+}
+
+// getLdapProtocolAndPort returns protocol and port of LDAP server with defaults applied
+func getLdapProtocolAndPort(ldap v1alpha1.NexusLdap) (string, int32) {
+	protocol, port := ldap.Protocol, ldap.Port
+	if len(protocol) == 0 {
+		protocol = "ldap"
+	}
+	if port == 0 {
+		port = 389
+		if protocol == "ldaps" {
+			port = 636
+		}
+	}
+	return protocol, port
+}
+
+// getLdapServer returns LDAP server from spec with defaults applied, bind password is read from Secret
+func (n NexusServiceImpl) getLdapServer(instance v1alpha1.Nexus) (*nexus.LdapServer, error) {
+	ldap := *instance.Spec.Ldap
+	server := nexus.LdapServer{
+		Name:                        ldap.Name,
+		UseTrustStore:               ldap.UseTrustStore,
+		Host:                        ldap.Host,
+		SearchBase:                  ldap.SearchBase,
+		AuthScheme:                  ldap.AuthScheme,
+		AuthRealm:                   ldap.AuthRealm,
+		AuthUsername:                ldap.BindDn,
+		ConnectionTimeoutSeconds:    ldap.ConnectionTimeoutSeconds,
+		ConnectionRetryDelaySeconds: ldap.ConnectionRetryDelaySeconds,
+		MaxIncidentsCount:           ldap.MaxIncidentsCount,
+		UserBaseDn:                  ldap.UserMapping.BaseDn,
+		UserSubtree:                 ldap.UserMapping.Subtree,
+		UserObjectClass:             ldap.UserMapping.ObjectClass,
+		UserLdapFilter:              ldap.UserMapping.LdapFilter,
+		UserIdAttribute:             ldap.UserMapping.IdAttribute,
+		UserRealNameAttribute:       ldap.UserMapping.RealNameAttribute,
+		UserEmailAddressAttribute:   ldap.UserMapping.EmailAddressAttribute,
+		UserPasswordAttribute:       ldap.UserMapping.PasswordAttribute,
+	}
+	server.Protocol, server.Port = getLdapProtocolAndPort(ldap)
+	if len(server.Name) == 0 {
+		server.Name = nexusDefaultSpec.NexusDefaultLdapServerName
+	}
+	if len(server.AuthScheme) == 0 {
+		server.AuthScheme = "NONE"
+		if len(ldap.BindDn) != 0 {
+			server.AuthScheme = "SIMPLE"
+		}
+	}
+	if server.ConnectionTimeoutSeconds == 0 {
+		server.ConnectionTimeoutSeconds = nexusDefaultSpec.NexusLdapConnectionTimeoutSeconds
+	}
+	if server.ConnectionRetryDelaySeconds == 0 {
+		server.ConnectionRetryDelaySeconds = nexusDefaultSpec.NexusLdapConnectionRetryDelaySeconds
+	}
+	if server.MaxIncidentsCount == 0 {
+		server.MaxIncidentsCount = nexusDefaultSpec.NexusLdapMaxIncidentsCount
+	}
+
+	if g := ldap.GroupMapping; g != nil {
+		server.LdapGroupsAsRoles = true
+		server.GroupType = g.Type
+		if len(server.GroupType) == 0 {
+			server.GroupType = "static"
+		}
+		server.GroupBaseDn = g.BaseDn
+		server.GroupSubtree = g.Subtree
+		server.GroupObjectClass = g.ObjectClass
+		server.GroupIdAttribute = g.IdAttribute
+		server.GroupMemberAttribute = g.MemberAttribute
+		server.GroupMemberFormat = g.MemberFormat
+		server.UserMemberOfAttribute = g.MemberOfAttribute
+	}
+
+	if server.AuthScheme == "NONE" {
+		return &server, nil
+	}
+	ref := ldap.BindPasswordSecretRef
+	if ref == nil {
+		return nil, fmt.Errorf("bind password Secret is required for %v authentication scheme", server.AuthScheme)
+	}
+	data, err := n.platformService.GetSecretData(instance.Namespace, ref.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get Secret %v", ref.Name)
+	}
+	password, ok := data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %v is not found in Secret %v", ref.Key, ref.Name)
+	}
+	server.AuthPassword = string(password)
+	return &server, nil
+}
+
+// getLdapScriptParameters returns parameters of setup-ldap script for LDAP server
+func getLdapScriptParameters(server nexus.LdapServer) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                     server.Name,
+		"protocol":                 server.Protocol,
+		"hostname":                 server.Host,
+		"port":                     strconv.Itoa(int(server.Port)),
+		"use_trust_store":          server.UseTrustStore,
+		"search_base":              server.SearchBase,
+		"auth_scheme":              ldapScriptAuthSchemes[server.AuthScheme],
+		"auth_realm":               server.AuthRealm,
+		"auth_username":            server.AuthUsername,
+		"auth_password":            server.AuthPassword,
+		"connection_timeout":       server.ConnectionTimeoutSeconds,
+		"connection_retry_delay":   server.ConnectionRetryDelaySeconds,
+		"max_incidents_count":      server.MaxIncidentsCount,
+		"user_base_dn":             server.UserBaseDn,
+		"user_subtree":             server.UserSubtree,
+		"user_object_class":        server.UserObjectClass,
+		"user_ldap_filter":         server.UserLdapFilter,
+		"user_id_attribute":        server.UserIdAttribute,
+		"user_real_name_attribute": server.UserRealNameAttribute,
+		"user_email_attribute":     server.UserEmailAddressAttribute,
+		"user_password_attribute":  server.UserPasswordAttribute,
+		"user_member_of_attribute": server.UserMemberOfAttribute,
+		"ldap_groups_as_roles":     server.LdapGroupsAsRoles,
+		"group_base_dn":            server.GroupBaseDn,
+		"group_subtree":            server.GroupSubtree,
+		"group_object_class":       server.GroupObjectClass,
+		"group_id_attribute":       server.GroupIdAttribute,
+		"group_member_attribute":   server.GroupMemberAttribute,
+		"group_member_format":      server.GroupMemberFormat,
+	}
+}
+
+// ldapAnnotationSuffix - annotation on Nexus CR which keeps hashes of bind passwords of LDAP servers, they are not
+// returned by REST API and can not be compared with the ones from Secret
+const ldapAnnotationSuffix = "ldap"
+
+// setupLdap creates LDAP server from spec or updates existing one if its settings or bind password have been changed
+func (n NexusServiceImpl) setupLdap(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient) error {
+	server, err := n.getLdapServer(*instance)
+	if err != nil {
+		return err
+	}
+
+	hashes := getTrackedHashes(*instance, ldapAnnotationSuffix)
+	hash := getScriptHash(server.AuthPassword)
+	existing, err := nexusClient.GetLdapServer(server.Name)
+	if err == nil {
+		if existing == nil {
+			err = nexusClient.CreateLdapServer(*server)
+		} else {
+			server.Id = existing.Id
+			withoutPassword := *server
+			withoutPassword.AuthPassword = ""
+			if *existing == withoutPassword && hashes[server.Name] == hash {
+				return nil
+			}
+			err = nexusClient.UpdateLdapServer(existing.Name, *server)
+		}
+		if err == nil {
+			hashes[server.Name] = hash
+			return n.setTrackedValue(instance, ldapAnnotationSuffix, hashes)
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.LdapApi, err, "setup-ldap", getLdapScriptParameters(*server))
+}
+
+// CheckLdapReachability checks that LDAP server from spec accepts connections, TLS handshake is performed for ldaps.
+// Bind is not performed and certificate is not verified since Nexus does both with its own settings and trust store
+func (n NexusServiceImpl) CheckLdapReachability(instance v1alpha1.Nexus) error {
+	if instance.Spec.Ldap == nil {
+		return nil
+	}
+	ldap := *instance.Spec.Ldap
+	protocol, port := getLdapProtocolAndPort(ldap)
+	timeout := ldap.ConnectionTimeoutSeconds
+	if timeout == 0 {
+		timeout = nexusDefaultSpec.NexusLdapConnectionTimeoutSeconds
+	}
+
+	address := net.JoinHostPort(ldap.Host, strconv.Itoa(int(port)))
+	dialer := &net.Dialer{Timeout: time.Duration(timeout) * time.Second}
+	var conn net.Conn
+	var err error
+	if protocol == "ldaps" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: ldap.Host, InsecureSkipVerify: true})
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to %v://%v", protocol, address)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,100 @@
+package nexus
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	coreV1Api "k8s.io/api/core/v1"
+	"reflect"
+	"testing"
+)
+
+func TestGetLdapProtocolAndPort(t *testing.T) {
+	tests := []struct {
+		ldap         v1alpha1.NexusLdap
+		wantProtocol string
+		wantPort     int32
+	}{
+		{ldap: v1alpha1.NexusLdap{}, wantProtocol: "ldap", wantPort: 389},
+		{ldap: v1alpha1.NexusLdap{Protocol: "ldaps"}, wantProtocol: "ldaps", wantPort: 636},
+		{ldap: v1alpha1.NexusLdap{Protocol: "ldaps", Port: 3269}, wantProtocol: "ldaps", wantPort: 3269},
+	}
+	for _, tt := range tests {
+		protocol, port := getLdapProtocolAndPort(tt.ldap)
+		if protocol != tt.wantProtocol || port != tt.wantPort {
+			t.Errorf("getLdapProtocolAndPort(%+v) = %v, %v, want %v, %v", tt.ldap, protocol, port, tt.wantProtocol, tt.wantPort)
+		}
+	}
+}
+
+func TestGetLdapServer(t *testing.T) {
+	secretRef := &coreV1Api.SecretKeySelector{LocalObjectReference: coreV1Api.LocalObjectReference{Name: "ldap"}, Key: "password"}
+	userMapping := v1alpha1.NexusLdapUserMapping{ObjectClass: "inetOrgPerson", IdAttribute: "uid"}
+	defaults := func(server nexus.LdapServer) *nexus.LdapServer {
+		server.Name = nexusDefaultSpec.NexusDefaultLdapServerName
+		server.Protocol = "ldap"
+		server.Port = 389
+		server.Host = "ldap.example.com"
+		server.SearchBase = "dc=example,dc=com"
+		server.UserObjectClass = "inetOrgPerson"
+		server.UserIdAttribute = "uid"
+		server.ConnectionTimeoutSeconds = nexusDefaultSpec.NexusLdapConnectionTimeoutSeconds
+		server.ConnectionRetryDelaySeconds = nexusDefaultSpec.NexusLdapConnectionRetryDelaySeconds
+		server.MaxIncidentsCount = nexusDefaultSpec.NexusLdapMaxIncidentsCount
+		return &server
+	}
+
+	tests := []struct {
+		name    string
+		ldap    v1alpha1.NexusLdap
+		want    *nexus.LdapServer
+		wantErr bool
+	}{
+		{
+			name: "anonymous bind",
+			ldap: v1alpha1.NexusLdap{Host: "ldap.example.com", SearchBase: "dc=example,dc=com", UserMapping: userMapping},
+			want: defaults(nexus.LdapServer{AuthScheme: "NONE"}),
+		},
+		{
+			name: "simple bind with password from Secret",
+			ldap: v1alpha1.NexusLdap{Host: "ldap.example.com", SearchBase: "dc=example,dc=com", UserMapping: userMapping,
+				BindDn: "cn=nexus,dc=example,dc=com", BindPasswordSecretRef: secretRef},
+			want: defaults(nexus.LdapServer{AuthScheme: "SIMPLE", AuthUsername: "cn=nexus,dc=example,dc=com", AuthPassword: "secret"}),
+		},
+		{
+			name: "static groups by default",
+			ldap: v1alpha1.NexusLdap{Host: "ldap.example.com", SearchBase: "dc=example,dc=com", UserMapping: userMapping,
+				GroupMapping: &v1alpha1.NexusLdapGroupMapping{MemberOfAttribute: "memberOf"}},
+			want: defaults(nexus.LdapServer{AuthScheme: "NONE", LdapGroupsAsRoles: true, GroupType: "static",
+				UserMemberOfAttribute: "memberOf"}),
+		},
+		{
+			name: "bind password Secret is not set",
+			ldap: v1alpha1.NexusLdap{Host: "ldap.example.com", SearchBase: "dc=example,dc=com", UserMapping: userMapping,
+				BindDn: "cn=nexus,dc=example,dc=com"},
+			wantErr: true,
+		},
+		{
+			name: "key is not found in Secret",
+			ldap: v1alpha1.NexusLdap{Host: "ldap.example.com", SearchBase: "dc=example,dc=com", UserMapping: userMapping,
+				AuthScheme: "DIGEST_MD5", BindDn: "nexus", BindPasswordSecretRef: &coreV1Api.SecretKeySelector{
+					LocalObjectReference: coreV1Api.LocalObjectReference{Name: "ldap"}, Key: "bindPassword"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NexusServiceImpl{platformService: secretPlatform{secrets: map[string]map[string][]byte{
+				"ldap": {"password": []byte("secret")},
+			}}}
+			ldap := tt.ldap
+			got, err := n.getLdapServer(v1alpha1.Nexus{Spec: v1alpha1.NexusSpec{Ldap: &ldap}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getLdapServer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getLdapServer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
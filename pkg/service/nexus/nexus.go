@@ -9,7 +9,6 @@ import (
 	"github.com/dchest/uniuri"
 	platformHelper "github.com/epmd-edp/jenkins-operator/v2/pkg/service/platform/helper"
 	keycloakV1Api "github.com/epmd-edp/keycloak-operator/pkg/apis/v1/v1alpha1"
-	keycloakControllerHelper "github.com/epmd-edp/keycloak-operator/pkg/controller/helper"
 	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
 	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
@@ -23,6 +22,7 @@ import (
 	"os"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"strings"
 )
 
 var log = logf.Log.WithName("nexus_service")
@@ -47,6 +47,18 @@ type NexusService interface {
 	ExposeConfiguration(instance v1alpha1.Nexus) (*v1alpha1.Nexus, error)
 	Integration(instance v1alpha1.Nexus) (*v1alpha1.Nexus, error)
 	IsDeploymentReady(instance v1alpha1.Nexus) (*bool, error)
+	CreateOrUpdateRepository(instance v1alpha1.Nexus, repository v1alpha1.NexusRepository) error
+	DeleteRepository(instance v1alpha1.Nexus, repository v1alpha1.NexusRepository) error
+	ValidateUpgrade(instance v1alpha1.Nexus) error
+	SetReadOnly(instance v1alpha1.Nexus, readOnly bool) error
+	Backup(instance v1alpha1.Nexus) (bool, error)
+	IsUpgraded(instance v1alpha1.Nexus) (bool, error)
+	Cleanup(instance v1alpha1.Nexus) error
+	CheckLdapReachability(instance v1alpha1.Nexus) error
+	GetBlobStoresUsage(instance v1alpha1.Nexus) ([]v1alpha1.NexusBlobStoreStatus, error)
+	GetTasksStatus(instance v1alpha1.Nexus) ([]v1alpha1.NexusTaskStatus, error)
+	GetTaskStatus(instance v1alpha1.Nexus, name string) (*v1alpha1.NexusTaskStatus, error)
+	RunTask(instance v1alpha1.Nexus, name string) (*v1alpha1.NexusTaskStatus, error)
 }
 
 // NewNexusService function that returns NexusService implementation
@@ -103,87 +115,67 @@ func (n NexusServiceImpl) setAnnotation(instance *v1alpha1.Nexus, key string, va
 
 // Integration performs integration Nexus with other EDP components
 func (n NexusServiceImpl) Integration(instance v1alpha1.Nexus) (*v1alpha1.Nexus, error) {
+	if !instance.Spec.KeycloakSpec.Enabled {
+		log.V(1).Info("Keycloak integration not enabled.")
+		return &instance, nil
+	}
 
-	if instance.Spec.KeycloakSpec.Enabled {
-		keycloakClient, err := n.platformService.GetKeycloakClient(instance.Name, instance.Namespace)
-		if err != nil {
-			return &instance, errors.Wrap(err, "failed to get Keycloak client data!")
-		}
-
-		keycloakRealm, err := keycloakControllerHelper.GetOwnerKeycloakRealm(n.k8sClient, keycloakClient.ObjectMeta)
-		if err != nil {
-			return &instance, nil
-		}
+	container, port, err := n.getAuthProxyContainer(instance)
+	if err != nil {
+		return &instance, err
+	}
 
-		if keycloakRealm == nil {
-			return &instance, errors.New("Keycloak Realm CR in not created yet!")
-		}
+	if err = n.platformService.AddAuthProxyToDeployConf(instance, *container); err != nil {
+		return &instance, errors.Wrap(err, "failed to add authentication proxy")
+	}
 
-		keycloak, err := keycloakControllerHelper.GetOwnerKeycloak(n.k8sClient, keycloakRealm.ObjectMeta)
-		if err != nil {
-			errMsg := fmt.Sprintf("failed to get owner for %s/%s", keycloakClient.Namespace, keycloakClient.Name)
-			return &instance, errors.Wrap(err, errMsg)
-		}
+	authProxyPort := coreV1Api.ServicePort{
+		Name:       nexusDefaultSpec.NexusAuthProxyContainerName,
+		Port:       port,
+		Protocol:   coreV1Api.ProtocolTCP,
+		TargetPort: intstr.IntOrString{IntVal: port},
+	}
+	if err = n.platformService.AddPortToService(instance, authProxyPort); err != nil {
+		return &instance, errors.Wrap(err, "failed to add authentication proxy port to service")
+	}
 
-		if keycloak == nil {
-			return &instance, errors.New("Keycloak CR is not created yet")
-		}
+	if err = n.platformService.UpdateExternalTargetPath(instance, intstr.IntOrString{IntVal: port}); err != nil {
+		return &instance, errors.Wrap(err, "failed to update target port in Route")
+	}
 
-		_, host, scheme, err := n.platformService.GetExternalUrl(instance.Namespace, instance.Name)
+	// Role mappings are synced for any proxy, so unsupported proxy is reported instead of ignoring the mappings
+	if isKeycloakClientRequired(instance) || len(instance.Spec.KeycloakSpec.RoleMappings) != 0 {
+		nexusClient, err := n.getNexusClient(instance)
 		if err != nil {
-			return &instance, errors.Wrap(err, "failed to get route")
+			return &instance, err
 		}
-
-		var proxyConfig []string
-		upstreamUrl := fmt.Sprintf("--upstream-url=http://127.0.0.1:%v", nexusDefaultSpec.NexusPort)
-		if len(instance.Spec.BasePath) != 0 {
-			upstreamUrl = fmt.Sprintf("%v/%v", upstreamUrl, instance.Spec.BasePath)
-			proxyConfig = append(proxyConfig, fmt.Sprintf("--base-uri=/%v", instance.Spec.BasePath))
-		}
-
-		ru := fmt.Sprintf("--redirection-url=%v", fmt.Sprintf("%v://%v", scheme, host))
-		id := fmt.Sprintf("--client-id=%v", keycloakClient.Spec.ClientId)
-		secret := fmt.Sprintf("--client-secret=42")
-		du := fmt.Sprintf("--discovery-url=%s/auth/realms/%s", keycloak.Spec.Url, keycloakRealm.Spec.RealmName)
-		uu := upstreamUrl
-
-		proxyConfig = append(
-			proxyConfig,
-			"--skip-openid-provider-tls-verify=true",
-			du,
-			id,
-			secret,
-			"--listen=0.0.0.0:3000",
-			ru,
-			uu,
-			"--resources=uri=/*|roles=developer,administrator|require-any-role=true",
-		)
-
-		err = n.platformService.AddKeycloakProxyToDeployConf(instance, proxyConfig)
+		annotations := copyAnnotations(instance)
+		err = n.syncRoleMappings(&instance, nexusClient)
+		n.saveTrackedAnnotations(&instance, annotations)
 		if err != nil {
-			return &instance, errors.Wrap(err, "failed to add Keycloak proxy")
-		}
-
-		keyCloakProxyPort := coreV1Api.ServicePort{
-			Name:       "keycloak-proxy",
-			Port:       nexusDefaultSpec.NexusKeycloakProxyPort,
-			Protocol:   coreV1Api.ProtocolTCP,
-			TargetPort: intstr.IntOrString{IntVal: nexusDefaultSpec.NexusKeycloakProxyPort},
+			return &instance, errors.Wrap(err, "failed to sync Keycloak role mappings")
 		}
-		if err = n.platformService.AddPortToService(instance, keyCloakProxyPort); err != nil {
-			return &instance, errors.Wrap(err, "failed to add Keycloak proxy port to service")
-		}
-
-		if err = n.platformService.UpdateExternalTargetPath(instance, intstr.IntOrString{IntVal: nexusDefaultSpec.NexusKeycloakProxyPort}); err != nil {
-			return &instance, errors.Wrap(err, "failed to update target port in Route")
-		}
-	} else {
-		log.V(1).Info("Keycloak integration not enabled.")
 	}
 
 	return &instance, nil
 }
 
+func getKeycloakClientSecretName(instance v1alpha1.Nexus) string {
+	return fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.IdentityServiceCredentialsSecretPostfix)
+}
+
+// checkKeycloakClientSecret checks that Secret with client secret of Keycloak client has been generated
+func (n NexusServiceImpl) checkKeycloakClientSecret(namespace string, name string) error {
+	data, err := n.platformService.GetSecretData(namespace, name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get Keycloak client secret %v", name)
+	}
+	if len(data[nexusDefaultSpec.KeycloakClientSecretKey]) == 0 {
+		return fmt.Errorf("Keycloak client secret %v does not contain %v yet", name, nexusDefaultSpec.KeycloakClientSecretKey)
+	}
+	return nil
+}
+
 // ExposeConfiguration performs exposing Nexus configuration for other EDP components
 func (n NexusServiceImpl) ExposeConfiguration(instance v1alpha1.Nexus) (*v1alpha1.Nexus, error) {
 	u, err := n.getNexusRestApiUrl(instance)
@@ -236,7 +228,7 @@ func (n NexusServiceImpl) ExposeConfiguration(instance v1alpha1.Nexus) (*v1alpha
 
 		userProperties["password"] = string(data["password"])
 
-		_, err = n.nexusClient.RunScript("setup-user", userProperties)
+		err = n.setupUser(&n.nexusClient, userProperties)
 		if err != nil {
 			return &instance, errors.Wrapf(err, "failed to create user %v ", userProperties["username"])
 		}
@@ -244,23 +236,34 @@ func (n NexusServiceImpl) ExposeConfiguration(instance v1alpha1.Nexus) (*v1alpha
 
 	_ = n.k8sClient.Update(context.TODO(), &instance)
 
-	if instance.Spec.KeycloakSpec.Enabled {
+	if isKeycloakClientRequired(instance) {
 		webURL, _, _, err := n.platformService.GetExternalUrl(instance.Namespace, instance.Name)
 		if err != nil {
 			return &instance, errors.Wrap(err, "failed to get route from cluster")
 		}
 
+		clientSecretName := getKeycloakClientSecretName(instance)
 		keycloakClient := keycloakV1Api.KeycloakClient{}
 		keycloakClient.Name = instance.Name
 		keycloakClient.Namespace = instance.Namespace
 		keycloakClient.Spec.ClientId = instance.Name
-		keycloakClient.Spec.Public = true
+		keycloakClient.Spec.Public = false
+		keycloakClient.Spec.Secret = clientSecretName
 		keycloakClient.Spec.WebUrl = webURL
 		keycloakClient.Spec.AudRequired = true
 
 		err = n.platformService.CreateKeycloakClient(&keycloakClient)
 		if err != nil {
-			return &instance, nil
+			return &instance, errors.Wrap(err, "failed to create Keycloak client")
+		}
+
+		// Clients created by previous versions of operator are public
+		if keycloakClient.Spec.Public || keycloakClient.Spec.Secret != clientSecretName {
+			keycloakClient.Spec.Public = false
+			keycloakClient.Spec.Secret = clientSecretName
+			if err = n.platformService.UpdateKeycloakClient(&keycloakClient); err != nil {
+				return &instance, err
+			}
 		}
 	}
 
@@ -333,19 +336,30 @@ func (n NexusServiceImpl) Configure(instance v1alpha1.Nexus) (*v1alpha1.Nexus, b
 		return &instance, false, nil
 	}
 
+	// Names and hashes of entities created by operator are saved to Nexus CR once per configuration
+	defer n.saveTrackedAnnotations(&instance, copyAnnotations(instance))
+
 	nexusDefaultScriptsToCreate, err := n.platformService.GetConfigMapData(instance.Namespace, fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusDefaultScriptsConfigMapPrefix))
 	if err != nil {
 		return &instance, false, errors.Wrap(err, "failed to get default tasks from Config Map")
 	}
 
-	err = n.nexusClient.DeclareDefaultScripts(nexusDefaultScriptsToCreate)
+	// Scripts are used only as a fallback for functionality missing in REST API, so Nexus with disabled
+	// script creation can still be configured
+	scriptsAreAvailable := true
+	err = n.declareScripts(&instance, &n.nexusClient, nexusDefaultScriptsToCreate)
 	if err != nil {
-		return &instance, false, errors.Wrap(err, "failed to upload default scripts")
-	}
-
-	defaultScriptsAreDeclared, err := n.nexusClient.AreDefaultScriptsDeclared(nexusDefaultScriptsToCreate)
-	if !defaultScriptsAreDeclared || err != nil {
-		return &instance, false, errors.Wrap(err, "default scripts are not uploaded yet")
+		if !nexus.IsErrScriptingDisabled(err) {
+			return &instance, false, errors.Wrap(err, "failed to upload default scripts")
+		}
+		log.Info("Script creation is disabled in Nexus, configuration relies on REST API only",
+			"Namespace", instance.Namespace, "Name", instance.Name)
+		scriptsAreAvailable = false
+	} else {
+		defaultScriptsAreDeclared, err := n.nexusClient.AreDefaultScriptsDeclared(nexusDefaultScriptsToCreate)
+		if !defaultScriptsAreDeclared || err != nil {
+			return &instance, false, errors.Wrap(err, "default scripts are not uploaded yet")
+		}
 	}
 
 	if nexusPassword == nexusDefaultSpec.NexusDefaultAdminPassword {
@@ -362,7 +376,7 @@ func (n NexusServiceImpl) Configure(instance v1alpha1.Nexus) (*v1alpha1.Nexus, b
 			return &instance, false, errors.Wrap(err, "failed to update Nexus admin secret with new password")
 		}
 
-		_, err = n.nexusClient.RunScript("update-admin-password", updatePasswordParameters)
+		err = n.changeAdminPassword(&n.nexusClient, updatePasswordParameters["new_password"].(string))
 		if err != nil {
 			return &instance, false, errors.Wrap(err, "failed to update admin password")
 		}
@@ -374,22 +388,7 @@ func (n NexusServiceImpl) Configure(instance v1alpha1.Nexus) (*v1alpha1.Nexus, b
 			return &instance, false, errors.Wrap(err, "failed to initialize Nexus client")
 		}
 	}
-	nexusDefaultTasksToCreate, err := n.platformService.GetConfigMapData(instance.Namespace, fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusDefaultTasksConfigMapPrefix))
-	if err != nil {
-		return &instance, false, errors.Wrap(err, "failed to get default tasks from Config Map")
-	}
-
-	var parsedTasks []map[string]interface{}
-	err = json.Unmarshal([]byte(nexusDefaultTasksToCreate[nexusDefaultSpec.NexusDefaultTasksConfigMapPrefix]), &parsedTasks)
-	for _, taskParameters := range parsedTasks {
-		_, err = n.nexusClient.RunScript("create-task", taskParameters)
-		if err != nil {
-			return &instance, false, errors.Wrapf(err, "failed to create task %v", taskParameters["name"])
-		}
-	}
-
-	var emptyParameter map[string]interface{}
-	_, err = n.nexusClient.RunScript("disable-outreach-capability", emptyParameter)
+	err = n.disableOutreachCapability(&n.nexusClient)
 	if err != nil {
 		return &instance, false, errors.Wrap(err, "failed to run disable-outreach-capability scripts")
 	}
@@ -403,36 +402,63 @@ func (n NexusServiceImpl) Configure(instance v1alpha1.Nexus) (*v1alpha1.Nexus, b
 	err = json.Unmarshal([]byte(nexusCapabilities["default-capabilities"]), &nexusParsedCapabilities)
 
 	for _, capability := range nexusParsedCapabilities {
-		_, err = n.nexusClient.RunScript("setup-capability", capability)
+		err = n.setupCapability(&n.nexusClient, capability)
 		if err != nil {
 			return &instance, false, errors.Wrap(err, "failed to install default capabilities")
 		}
 	}
 
-	enabledRealms := []map[string]interface{}{
-		{"name": "NuGetApiKey"},
+	if err = validateRealms(instance); err != nil {
+		return &instance, false, err
 	}
-	for _, realmName := range enabledRealms {
-		_, err = n.nexusClient.RunScript("enable-realm", realmName)
-		if err != nil {
-			return &instance, false, errors.Wrapf(err, "failed to enable %v realm", enabledRealms)
+	enabledRealms := []string{"NuGetApiKey"}
+	if instance.Spec.Ldap != nil {
+		if err = n.setupLdap(&instance, &n.nexusClient); err != nil {
+			return &instance, false, errors.Wrap(err, "failed to configure LDAP server")
+		}
+		enabledRealms = append(enabledRealms, nexusDefaultSpec.NexusLdapRealm)
+	}
+	if len(instance.Spec.Realms) != 0 {
+		if err = n.setupRealms(&n.nexusClient, instance.Spec.Realms); err != nil {
+			return &instance, false, errors.Wrap(err, "failed to set up realms")
+		}
+	} else {
+		for _, realmName := range enabledRealms {
+			err = n.enableRealm(&n.nexusClient, realmName)
+			if err != nil {
+				return &instance, false, errors.Wrapf(err, "failed to enable %v realm", realmName)
+			}
 		}
 	}
 
-	nexusDefaultRolesToCreate, err := n.platformService.GetConfigMapData(instance.Namespace, fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusDefaultRolesConfigMapPrefix))
-	if err != nil {
-		return &instance, false, errors.Wrap(err, "failed to get default roles from Config Map")
+	if instance.Spec.AnonymousAccess != nil {
+		if err = n.setupAnonymousAccess(&n.nexusClient, *instance.Spec.AnonymousAccess); err != nil {
+			return &instance, false, errors.Wrap(err, "failed to set up anonymous access")
+		}
 	}
 
-	var parsedRoles []map[string]interface{}
-	err = json.Unmarshal([]byte(nexusDefaultRolesToCreate[nexusDefaultSpec.NexusDefaultRolesConfigMapPrefix]), &parsedRoles)
-	for _, roleParameters := range parsedRoles {
-		_, err := n.nexusClient.RunScript("setup-role", roleParameters)
-		if err != nil {
-			return &instance, false, errors.Wrapf(err, "failed to create role %v", roleParameters["name"])
+	webUrl, _, _, err := n.platformService.GetExternalUrl(instance.Namespace, instance.Name)
+	if err != nil {
+		return &instance, false, errors.Wrap(err, "failed to get external URL")
+	}
+	if len(webUrl) != 0 {
+		if err = n.setBaseUrl(&n.nexusClient, webUrl); err != nil {
+			return &instance, false, errors.Wrap(err, "failed to set base URL")
 		}
 	}
 
+	if err = n.syncPrivileges(&instance, &n.nexusClient); err != nil {
+		return &instance, false, errors.Wrap(err, "failed to set up content selectors and privileges")
+	}
+
+	parsedRoles, err := n.getDefaultRoles(instance)
+	if err != nil {
+		return &instance, false, err
+	}
+	if err = n.syncRoles(&instance, &n.nexusClient, parsedRoles); err != nil {
+		return &instance, false, errors.Wrap(err, "failed to set up roles")
+	}
+
 	// Creating blob storage configuration from config map
 	blobsConfig, err := n.platformService.GetConfigMapData(instance.Namespace, fmt.Sprintf("%v-blobs", instance.Name))
 	if err != nil {
@@ -446,12 +472,42 @@ func (n NexusServiceImpl) Configure(instance v1alpha1.Nexus) (*v1alpha1.Nexus, b
 	}
 
 	for _, blob := range parsedBlobsConfig {
-		_, err := n.nexusClient.RunScript("create-blobstore", blob)
+		err := n.createBlobStore(&n.nexusClient, blob)
 		if err != nil {
 			return &instance, false, errors.Wrapf(err, "failed to create blob store %v", blob["name"])
 		}
 	}
 
+	for _, blobStore := range instance.Spec.BlobStores {
+		if err := n.setupBlobStore(&instance, &n.nexusClient, blobStore); err != nil {
+			return &instance, false, errors.Wrapf(err, "failed to set up blob store %v", blobStore.Name)
+		}
+	}
+
+	if err = n.syncCleanupPolicies(&instance, &n.nexusClient); err != nil {
+		return &instance, false, errors.Wrap(err, "failed to set up cleanup policies")
+	}
+
+	nexusDefaultTasksToCreate, err := n.platformService.GetConfigMapData(instance.Namespace, fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusDefaultTasksConfigMapPrefix))
+	if err != nil {
+		return &instance, false, errors.Wrap(err, "failed to get default tasks from Config Map")
+	}
+
+	var parsedTasks []map[string]interface{}
+	if tasks, ok := nexusDefaultTasksToCreate[nexusDefaultSpec.NexusDefaultTasksConfigMapPrefix]; ok {
+		if err = json.Unmarshal([]byte(tasks), &parsedTasks); err != nil {
+			return &instance, false, errors.Wrap(err, "failed to unmarshal default tasks")
+		}
+	}
+
+	// REST API doesn't allow to create tasks
+	if !scriptsAreAvailable {
+		log.Info("Skipping reconciliation of tasks since scripts are not available",
+			"Namespace", instance.Namespace, "Name", instance.Name)
+	} else if err = n.syncTasks(&instance, &n.nexusClient, getDeclaredTasks(instance, parsedTasks)); err != nil {
+		return &instance, false, errors.Wrap(err, "failed to reconcile tasks")
+	}
+
 	// Creating repositoriesToCreate from config map
 	reposToCreate, err := n.platformService.GetConfigMapData(instance.Namespace, fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusDefaultReposToCreateConfigMapPrefix))
 	if err != nil {
@@ -466,8 +522,11 @@ func (n NexusServiceImpl) Configure(instance v1alpha1.Nexus) (*v1alpha1.Nexus, b
 
 	for _, repositoryToCreate := range parsedReposToCreate {
 		repositoryName := repositoryToCreate["name"].(string)
-		repositoryType := repositoryToCreate["repositoryType"].(string)
-		_, err := n.nexusClient.RunScript(fmt.Sprintf("create-repo-%v", repositoryType), repositoryToCreate)
+		repositoryType := strings.SplitN(repositoryToCreate["repositoryType"].(string), "-", 2)
+		if len(repositoryType) != 2 {
+			return &instance, false, fmt.Errorf("repository type of %v should be in <format>-<type> form", repositoryName)
+		}
+		err := n.createOrUpdateRepository(&n.nexusClient, repositoryType[0], repositoryType[1], repositoryToCreate)
 		if err != nil {
 			return &instance, false, errors.Wrapf(err, "failed to create repository %v", repositoryName)
 		}
@@ -485,26 +544,14 @@ func (n NexusServiceImpl) Configure(instance v1alpha1.Nexus) (*v1alpha1.Nexus, b
 	}
 
 	for _, repositoryToDelete := range parsedReposToDelete {
-		_, err := n.nexusClient.RunScript("delete-repo", repositoryToDelete)
+		err := n.deleteRepository(&n.nexusClient, getStringParameter(repositoryToDelete, "name"))
 		if err != nil {
 			return &instance, false, errors.Wrapf(err, "failed to delete repository %v", repositoryToDelete)
 		}
 	}
 
-	for _, user := range instance.Spec.Users {
-		setupUserParameters := map[string]interface{}{
-			"username":   user.Username,
-			"first_name": user.FirstName,
-			"last_name":  user.LastName,
-			"email":      user.Email,
-			"password":   uniuri.New(),
-			"roles":      user.Roles,
-		}
-
-		_, err = n.nexusClient.RunScript("setup-user", setupUserParameters)
-		if err != nil {
-			return &instance, false, errors.Wrapf(err, "failed to create user %v", user.Username)
-		}
+	if err = n.syncUsers(&instance, &n.nexusClient); err != nil {
+		return &instance, false, errors.Wrap(err, "failed to set up users")
 	}
 
 	return &instance, true, nil
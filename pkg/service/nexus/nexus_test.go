@@ -0,0 +1,44 @@
+package nexus
+
+import (
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform"
+	"testing"
+)
+
+// secretPlatform returns data of Secrets from the map, other methods of platform service are not implemented
+type secretPlatform struct {
+	platform.PlatformService
+	secrets map[string]map[string][]byte
+}
+
+func (p secretPlatform) GetSecretData(namespace string, name string) (map[string][]byte, error) {
+	return p.secrets[name], nil
+}
+
+func TestCheckKeycloakClientSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets map[string]map[string][]byte
+		wantErr bool
+	}{
+		{
+			name:    "generated client secret",
+			secrets: map[string]map[string][]byte{"nexus-is-credentials": {nexusDefaultSpec.KeycloakClientSecretKey: []byte("secret")}},
+		},
+		{name: "Secret is not created yet", wantErr: true},
+		{
+			name:    "client secret is not generated yet",
+			secrets: map[string]map[string][]byte{"nexus-is-credentials": {"clientId": []byte("nexus")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NexusServiceImpl{platformService: secretPlatform{secrets: tt.secrets}}
+			if err := n.checkKeycloakClientSecret("edp", "nexus-is-credentials"); (err != nil) != tt.wantErr {
+				t.Errorf("checkKeycloakClientSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
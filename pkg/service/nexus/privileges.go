@@ -0,0 +1,273 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	"github.com/pkg/errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// contentSelectorsAnnotationSuffix - annotation on Nexus CR which keeps names of content selectors created by operator
+	contentSelectorsAnnotationSuffix = "content-selectors"
+	// privilegesAnnotationSuffix - annotation on Nexus CR which keeps names of privileges created by operator
+	privilegesAnnotationSuffix = "privileges"
+)
+
+// privilegeActions are actions which can be granted by privileges of the given type
+var privilegeActions = map[v1alpha1.PrivilegeType][]string{
+	v1alpha1.PrivilegeTypeRepositoryView:            {"BROWSE", "READ", "EDIT", "ADD", "DELETE", "ALL"},
+	v1alpha1.PrivilegeTypeRepositoryContentSelector: {"BROWSE", "READ", "EDIT", "ADD", "DELETE", "ALL"},
+	v1alpha1.PrivilegeTypeScript:                    {"BROWSE", "READ", "EDIT", "ADD", "DELETE", "RUN", "ALL"},
+}
+
+// validateContentSelectors checks that content selectors have unique names and expressions
+func validateContentSelectors(selectors []v1alpha1.NexusContentSelector) error {
+	var names []string
+	for _, s := range selectors {
+		if len(s.Name) == 0 {
+			return errors.New("name of content selector is not set")
+		}
+		if controllerHelper.ContainsString(names, s.Name) {
+			return fmt.Errorf("content selector %v is declared more than once", s.Name)
+		}
+		if len(strings.TrimSpace(s.Expression)) == 0 {
+			return fmt.Errorf("expression of content selector %v is not set", s.Name)
+		}
+		names = append(names, s.Name)
+	}
+	return nil
+}
+
+// validatePrivilege checks that privilege has the fields which are required by its type and grants supported actions
+func validatePrivilege(privilege v1alpha1.NexusPrivilege) error {
+	allowedActions, ok := privilegeActions[privilege.Type]
+	if !ok {
+		return fmt.Errorf("privilege type %v is not supported", privilege.Type)
+	}
+	if len(privilege.Actions) == 0 {
+		return errors.New("at least one action must be set")
+	}
+	for _, action := range privilege.Actions {
+		if !controllerHelper.ContainsString(allowedActions, strings.ToUpper(action)) {
+			return fmt.Errorf("action %v is not supported by %v privilege", action, privilege.Type)
+		}
+	}
+
+	if privilege.Type == v1alpha1.PrivilegeTypeScript {
+		if len(privilege.ScriptName) == 0 {
+			return errors.New("script name is mandatory for script privilege")
+		}
+		if len(privilege.Format) != 0 || len(privilege.Repository) != 0 || len(privilege.ContentSelector) != 0 {
+			return errors.New("format, repository and content selector can not be set for script privilege")
+		}
+		return nil
+	}
+	if len(privilege.Format) == 0 || len(privilege.Repository) == 0 {
+		return fmt.Errorf("format and repository are mandatory for %v privilege", privilege.Type)
+	}
+	if len(privilege.ScriptName) != 0 {
+		return fmt.Errorf("script name can not be set for %v privilege", privilege.Type)
+	}
+	if privilege.Type == v1alpha1.PrivilegeTypeRepositoryContentSelector && len(privilege.ContentSelector) == 0 {
+		return errors.New("content selector is mandatory for repository-content-selector privilege")
+	}
+	if privilege.Type == v1alpha1.PrivilegeTypeRepositoryView && len(privilege.ContentSelector) != 0 {
+		return errors.New("content selector can not be set for repository-view privilege")
+	}
+	return nil
+}
+
+// validatePrivileges checks privileges from spec. Content selector of privilege must be declared in spec or exist in Nexus
+func validatePrivileges(nexusClient *nexus.NexusClient, spec v1alpha1.NexusSpec) error {
+	var selectors []string
+	for _, s := range spec.ContentSelectors {
+		selectors = append(selectors, s.Name)
+	}
+
+	var names []string
+	for _, p := range spec.Privileges {
+		if len(p.Name) == 0 {
+			return errors.New("name of privilege is not set")
+		}
+		if controllerHelper.ContainsString(names, p.Name) {
+			return fmt.Errorf("privilege %v is declared more than once", p.Name)
+		}
+		if err := validatePrivilege(p); err != nil {
+			return errors.Wrapf(err, "privilege %v is invalid", p.Name)
+		}
+		names = append(names, p.Name)
+
+		if len(p.ContentSelector) == 0 || controllerHelper.ContainsString(selectors, p.ContentSelector) {
+			continue
+		}
+		existing, err := nexusClient.GetContentSelector(p.ContentSelector)
+		if err != nil {
+			// Content selectors API is missing in old Nexus versions, the reference is checked by Nexus then
+			if nexus.IsErrNotSupported(err) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to get content selector %v", p.ContentSelector)
+		}
+		if existing == nil {
+			return fmt.Errorf("content selector %v of privilege %v is not found", p.ContentSelector, p.Name)
+		}
+		selectors = append(selectors, p.ContentSelector)
+	}
+	return nil
+}
+
+// newPrivilegeApiModel converts privilege from spec to the model of privileges REST API
+func newPrivilegeApiModel(privilege v1alpha1.NexusPrivilege) nexus.Privilege {
+	var actions []string
+	for _, action := range privilege.Actions {
+		actions = append(actions, strings.ToUpper(action))
+	}
+	sort.Strings(actions)
+	return nexus.Privilege{
+		Type:            string(privilege.Type),
+		Name:            privilege.Name,
+		Description:     privilege.Description,
+		Actions:         actions,
+		Format:          privilege.Format,
+		Repository:      privilege.Repository,
+		ContentSelector: privilege.ContentSelector,
+		ScriptName:      privilege.ScriptName,
+	}
+}
+
+// setupContentSelector creates content selector or updates existing one
+func (n NexusServiceImpl) setupContentSelector(nexusClient *nexus.NexusClient, selector v1alpha1.NexusContentSelector) error {
+	model := nexus.ContentSelector{Name: selector.Name, Description: selector.Description, Expression: selector.Expression}
+	existing, err := nexusClient.GetContentSelector(selector.Name)
+	if err == nil {
+		if existing == nil {
+			err = nexusClient.CreateContentSelector(model)
+		} else if existing.Description != model.Description || existing.Expression != model.Expression {
+			err = nexusClient.UpdateContentSelector(model)
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.ContentSelectorsApi, err, "setup-content-selector", map[string]interface{}{
+		"name":        selector.Name,
+		"description": selector.Description,
+		"expression":  selector.Expression,
+	})
+}
+
+// deleteContentSelector deletes content selector, selector which doesn't exist is ignored
+func (n NexusServiceImpl) deleteContentSelector(nexusClient *nexus.NexusClient, name string) error {
+	existing, err := nexusClient.GetContentSelector(name)
+	if err == nil {
+		if existing == nil {
+			return nil
+		}
+		err = nexusClient.DeleteContentSelector(name)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.ContentSelectorsApi, err, "delete-content-selector", map[string]interface{}{"name": name})
+}
+
+// setupPrivilege creates privilege or updates existing one. Type of existing privilege can not be changed
+// and built-in privileges are not modified
+func (n NexusServiceImpl) setupPrivilege(nexusClient *nexus.NexusClient, privilege nexus.Privilege) error {
+	existing, err := nexusClient.GetPrivilege(privilege.Name)
+	if err == nil {
+		if existing == nil {
+			err = nexusClient.CreatePrivilege(privilege)
+		} else {
+			if existing.ReadOnly {
+				return errors.New("built-in privilege can not be changed")
+			}
+			if existing.Type != privilege.Type {
+				return fmt.Errorf("privilege of %v type already exists, its type can not be changed", existing.Type)
+			}
+			sort.Strings(existing.Actions)
+			if !reflect.DeepEqual(*existing, privilege) {
+				err = nexusClient.UpdatePrivilege(privilege)
+			}
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.PrivilegesApi, err, "setup-privilege", map[string]interface{}{
+		"name":             privilege.Name,
+		"description":      privilege.Description,
+		"type":             privilege.Type,
+		"actions":          privilege.Actions,
+		"format":           privilege.Format,
+		"repository":       privilege.Repository,
+		"content_selector": privilege.ContentSelector,
+		"script_name":      privilege.ScriptName,
+	})
+}
+
+// deletePrivilege deletes privilege, privilege which doesn't exist is ignored
+func (n NexusServiceImpl) deletePrivilege(nexusClient *nexus.NexusClient, name string) error {
+	existing, err := nexusClient.GetPrivilege(name)
+	if err == nil {
+		if existing == nil {
+			return nil
+		}
+		err = nexusClient.DeletePrivilege(name)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.PrivilegesApi, err, "delete-privilege", map[string]interface{}{"name": name})
+}
+
+// syncPrivileges validates content selectors and privileges from spec, creates or updates them and deletes the ones
+// which have been created by operator but removed from spec. Privileges are deleted before content selectors
+// since Nexus doesn't allow to delete content selector which is used by privilege
+func (n NexusServiceImpl) syncPrivileges(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient) error {
+	if err := validateContentSelectors(instance.Spec.ContentSelectors); err != nil {
+		return err
+	}
+	if err := validatePrivileges(nexusClient, instance.Spec); err != nil {
+		return err
+	}
+
+	var declaredSelectors []string
+	for _, selector := range instance.Spec.ContentSelectors {
+		if err := n.setupContentSelector(nexusClient, selector); err != nil {
+			return errors.Wrapf(err, "failed to set up content selector %v", selector.Name)
+		}
+		declaredSelectors = append(declaredSelectors, selector.Name)
+	}
+
+	var declaredPrivileges []string
+	for _, privilege := range instance.Spec.Privileges {
+		if err := n.setupPrivilege(nexusClient, newPrivilegeApiModel(privilege)); err != nil {
+			return errors.Wrapf(err, "failed to set up privilege %v", privilege.Name)
+		}
+		declaredPrivileges = append(declaredPrivileges, privilege.Name)
+	}
+
+	createdPrivileges := getCreatedNames(*instance, privilegesAnnotationSuffix)
+	for _, name := range createdPrivileges {
+		if controllerHelper.ContainsString(declaredPrivileges, name) {
+			continue
+		}
+		if err := n.deletePrivilege(nexusClient, name); err != nil {
+			return errors.Wrapf(err, "failed to delete privilege %v", name)
+		}
+		log.Info("Privilege has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "Privilege", name)
+	}
+
+	createdSelectors := getCreatedNames(*instance, contentSelectorsAnnotationSuffix)
+	for _, name := range createdSelectors {
+		if controllerHelper.ContainsString(declaredSelectors, name) {
+			continue
+		}
+		if err := n.deleteContentSelector(nexusClient, name); err != nil {
+			return errors.Wrapf(err, "failed to delete content selector %v", name)
+		}
+		log.Info("Content selector has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "ContentSelector", name)
+	}
+
+	if reflect.DeepEqual(createdPrivileges, declaredPrivileges) && reflect.DeepEqual(createdSelectors, declaredSelectors) {
+		return nil
+	}
+	if err := n.setTrackedValue(instance, privilegesAnnotationSuffix, declaredPrivileges); err != nil {
+		return err
+	}
+	return n.setTrackedValue(instance, contentSelectorsAnnotationSuffix, declaredSelectors)
+}
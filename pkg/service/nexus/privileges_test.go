@@ -0,0 +1,81 @@
+package nexus
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"testing"
+)
+
+func TestValidateContentSelectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors []v1alpha1.NexusContentSelector
+		wantErr   bool
+	}{
+		{name: "no selectors"},
+		{name: "valid selectors", selectors: []v1alpha1.NexusContentSelector{
+			{Name: "team-a", Expression: `format == "maven2" and path =^ "/com/acme/team-a/"`},
+			{Name: "team-b", Expression: `format == "maven2" and path =^ "/com/acme/team-b/"`},
+		}},
+		{name: "name is not set", selectors: []v1alpha1.NexusContentSelector{
+			{Expression: `format == "maven2"`},
+		}, wantErr: true},
+		{name: "duplicated name", selectors: []v1alpha1.NexusContentSelector{
+			{Name: "team-a", Expression: `format == "maven2"`},
+			{Name: "team-a", Expression: `format == "npm"`},
+		}, wantErr: true},
+		{name: "blank expression", selectors: []v1alpha1.NexusContentSelector{
+			{Name: "team-a", Expression: " "},
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateContentSelectors(tt.selectors)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateContentSelectors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePrivilege(t *testing.T) {
+	tests := []struct {
+		name      string
+		privilege v1alpha1.NexusPrivilege
+		wantErr   bool
+	}{
+		{name: "repository view", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryView,
+			Actions: []string{"BROWSE", "READ"}, Format: "maven2", Repository: "*"}},
+		{name: "lower case action", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryView,
+			Actions: []string{"read"}, Format: "*", Repository: "*"}},
+		{name: "repository content selector", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryContentSelector,
+			Actions: []string{"ALL"}, Format: "maven2", Repository: "maven-releases", ContentSelector: "team-a"}},
+		{name: "script", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeScript,
+			Actions: []string{"RUN"}, ScriptName: "*"}},
+		{name: "unsupported type", privilege: v1alpha1.NexusPrivilege{Type: "wildcard",
+			Actions: []string{"ALL"}}, wantErr: true},
+		{name: "no actions", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryView,
+			Format: "maven2", Repository: "*"}, wantErr: true},
+		{name: "run action of repository", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryView,
+			Actions: []string{"RUN"}, Format: "maven2", Repository: "*"}, wantErr: true},
+		{name: "script without name", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeScript,
+			Actions: []string{"RUN"}}, wantErr: true},
+		{name: "script with repository", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeScript,
+			Actions: []string{"RUN"}, ScriptName: "*", Repository: "*"}, wantErr: true},
+		{name: "repository view without format", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryView,
+			Actions: []string{"READ"}, Repository: "*"}, wantErr: true},
+		{name: "repository view with script name", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryView,
+			Actions: []string{"READ"}, Format: "*", Repository: "*", ScriptName: "*"}, wantErr: true},
+		{name: "repository view with content selector", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryView,
+			Actions: []string{"READ"}, Format: "*", Repository: "*", ContentSelector: "team-a"}, wantErr: true},
+		{name: "repository content selector without selector", privilege: v1alpha1.NexusPrivilege{Type: v1alpha1.PrivilegeTypeRepositoryContentSelector,
+			Actions: []string{"READ"}, Format: "*", Repository: "*"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePrivilege(tt.privilege)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePrivilege() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
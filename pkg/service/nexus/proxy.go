@@ -0,0 +1,245 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/dchest/uniuri"
+	keycloakControllerHelper "github.com/epmd-edp/keycloak-operator/pkg/controller/helper"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform"
+	"github.com/pkg/errors"
+	coreV1Api "k8s.io/api/core/v1"
+	"strings"
+)
+
+// authProxyDefaultRoles - Keycloak roles which are allowed to access Nexus if roles are not set in spec
+var authProxyDefaultRoles = []string{"developer", "administrator"}
+
+// oidcSettings describes Keycloak client which is used by authentication proxy
+type oidcSettings struct {
+	clientId         string
+	clientSecretName string
+	issuerUrl        string
+	externalUrl      string
+}
+
+// getAuthProxy returns authentication proxy spec with defaults applied
+func getAuthProxy(instance v1alpha1.Nexus) v1alpha1.NexusAuthProxy {
+	proxy := v1alpha1.NexusAuthProxy{}
+	if instance.Spec.AuthProxy != nil {
+		proxy = *instance.Spec.AuthProxy
+	}
+	if len(proxy.Type) == 0 {
+		proxy.Type = v1alpha1.AuthProxyGatekeeper
+	}
+	if len(proxy.Roles) == 0 && proxy.Type != v1alpha1.AuthProxyOpenshiftOAuthProxy {
+		proxy.Roles = authProxyDefaultRoles
+	}
+	return proxy
+}
+
+// isKeycloakClientRequired checks if authentication proxy authenticates users in Keycloak
+func isKeycloakClientRequired(instance v1alpha1.Nexus) bool {
+	return instance.Spec.KeycloakSpec.Enabled && getAuthProxy(instance).Type != v1alpha1.AuthProxyOpenshiftOAuthProxy
+}
+
+func getUpstreamUrl(instance v1alpha1.Nexus) string {
+	upstreamUrl := fmt.Sprintf("http://127.0.0.1:%v", nexusDefaultSpec.NexusPort)
+	if len(instance.Spec.BasePath) != 0 {
+		upstreamUrl = fmt.Sprintf("%v/%v", upstreamUrl, instance.Spec.BasePath)
+	}
+	return upstreamUrl
+}
+
+func getSecretEnv(name string, secretName string, key string) coreV1Api.EnvVar {
+	return coreV1Api.EnvVar{
+		Name: name,
+		ValueFrom: &coreV1Api.EnvVarSource{
+			SecretKeyRef: &coreV1Api.SecretKeySelector{
+				LocalObjectReference: coreV1Api.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// getOidcSettings collects settings of Keycloak client of Nexus, it fails if client secret has not been generated yet
+func (n NexusServiceImpl) getOidcSettings(instance v1alpha1.Nexus) (*oidcSettings, error) {
+	keycloakClient, err := n.platformService.GetKeycloakClient(instance.Name, instance.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get Keycloak client data!")
+	}
+
+	keycloakRealm, err := keycloakControllerHelper.GetOwnerKeycloakRealm(n.k8sClient, keycloakClient.ObjectMeta)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get owner Keycloak Realm")
+	}
+
+	if keycloakRealm == nil {
+		return nil, errors.New("Keycloak Realm CR in not created yet!")
+	}
+
+	keycloak, err := keycloakControllerHelper.GetOwnerKeycloak(n.k8sClient, keycloakRealm.ObjectMeta)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to get owner for %s/%s", keycloakClient.Namespace, keycloakClient.Name)
+		return nil, errors.Wrap(err, errMsg)
+	}
+
+	if keycloak == nil {
+		return nil, errors.New("Keycloak CR is not created yet")
+	}
+
+	// Proxy must not be started without client secret, otherwise Nexus would be exposed without authentication
+	clientSecretName := getKeycloakClientSecretName(instance)
+	if err = n.checkKeycloakClientSecret(instance.Namespace, clientSecretName); err != nil {
+		return nil, err
+	}
+
+	_, host, scheme, err := n.platformService.GetExternalUrl(instance.Namespace, instance.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get route")
+	}
+
+	return &oidcSettings{
+		clientId:         keycloakClient.Spec.ClientId,
+		clientSecretName: clientSecretName,
+		issuerUrl:        fmt.Sprintf("%s/auth/realms/%s", keycloak.Spec.Url, keycloakRealm.Spec.RealmName),
+		externalUrl:      fmt.Sprintf("%v://%v", scheme, host),
+	}, nil
+}
+
+// createCookieSecret creates Secret with random cookie secret of oauth2-proxy if it does not exist yet
+func (n NexusServiceImpl) createCookieSecret(instance v1alpha1.Nexus) (string, error) {
+	name := fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusAuthProxyCookieSecretPostfix)
+	data := map[string][]byte{
+		nexusDefaultSpec.NexusAuthProxyCookieSecretKey: []byte(uniuri.NewLen(32)),
+	}
+	if err := n.platformService.CreateSecret(instance, name, data); err != nil {
+		return "", errors.Wrapf(err, "failed to create %s secret", name)
+	}
+	return name, nil
+}
+
+// getAuthProxyContainer returns sidecar container of authentication proxy of the type set in spec and its port
+func (n NexusServiceImpl) getAuthProxyContainer(instance v1alpha1.Nexus) (*coreV1Api.Container, int32, error) {
+	proxy := getAuthProxy(instance)
+	var args []string
+	var env []coreV1Api.EnvVar
+	var port int32
+	image := proxy.Image
+
+	switch proxy.Type {
+	case v1alpha1.AuthProxyGatekeeper:
+		oidc, err := n.getOidcSettings(instance)
+		if err != nil {
+			return nil, 0, err
+		}
+		port = nexusDefaultSpec.NexusKeycloakProxyPort
+		if len(image) == 0 {
+			image = nexusDefaultSpec.NexusKeycloakProxyImage
+		}
+		if len(instance.Spec.BasePath) != 0 {
+			args = append(args, fmt.Sprintf("--base-uri=/%v", instance.Spec.BasePath))
+		}
+		args = append(args,
+			"--skip-openid-provider-tls-verify=true",
+			fmt.Sprintf("--discovery-url=%s", oidc.issuerUrl),
+			fmt.Sprintf("--client-id=%v", oidc.clientId),
+			fmt.Sprintf("--listen=0.0.0.0:%v", port),
+			fmt.Sprintf("--redirection-url=%v", oidc.externalUrl),
+			fmt.Sprintf("--upstream-url=%v", getUpstreamUrl(instance)),
+			fmt.Sprintf("--resources=uri=/*|roles=%v|require-any-role=true", strings.Join(proxy.Roles, ",")),
+		)
+		env = append(env, getSecretEnv("PROXY_CLIENT_SECRET", oidc.clientSecretName, nexusDefaultSpec.KeycloakClientSecretKey))
+	case v1alpha1.AuthProxyOAuth2Proxy:
+		oidc, err := n.getOidcSettings(instance)
+		if err != nil {
+			return nil, 0, err
+		}
+		cookieSecretName, err := n.createCookieSecret(instance)
+		if err != nil {
+			return nil, 0, err
+		}
+		port = nexusDefaultSpec.NexusOAuth2ProxyPort
+		if len(image) == 0 {
+			image = nexusDefaultSpec.NexusOAuth2ProxyImage
+		}
+		prefix := "/oauth2"
+		if len(instance.Spec.BasePath) != 0 {
+			prefix = fmt.Sprintf("/%v%v", instance.Spec.BasePath, prefix)
+		}
+		args = append(args,
+			"--provider=keycloak-oidc",
+			"--email-domain=*",
+			fmt.Sprintf("--oidc-issuer-url=%v", oidc.issuerUrl),
+			fmt.Sprintf("--client-id=%v", oidc.clientId),
+			fmt.Sprintf("--http-address=0.0.0.0:%v", port),
+			fmt.Sprintf("--proxy-prefix=%v", prefix),
+			fmt.Sprintf("--redirect-url=%v%v/callback", oidc.externalUrl, prefix),
+			fmt.Sprintf("--cookie-secure=%v", strings.HasPrefix(oidc.externalUrl, "https")),
+			fmt.Sprintf("--upstream=%v", getUpstreamUrl(instance)),
+		)
+		for _, role := range proxy.Roles {
+			args = append(args, fmt.Sprintf("--allowed-role=%v", role))
+		}
+		if proxy.InsecureSkipVerify {
+			args = append(args, "--ssl-insecure-skip-verify=true")
+		}
+		env = append(env,
+			getSecretEnv("OAUTH2_PROXY_CLIENT_SECRET", oidc.clientSecretName, nexusDefaultSpec.KeycloakClientSecretKey),
+			getSecretEnv("OAUTH2_PROXY_COOKIE_SECRET", cookieSecretName, nexusDefaultSpec.NexusAuthProxyCookieSecretKey),
+		)
+	case v1alpha1.AuthProxyOpenshiftOAuthProxy:
+		if strings.ToLower(controllerHelper.GetPlatformTypeEnv()) != platform.Openshift {
+			return nil, 0, fmt.Errorf("%v can be used only on OpenShift", proxy.Type)
+		}
+		// OpenShift OAuth server accepts service account as a client if redirect to the route is allowed
+		redirectReference := fmt.Sprintf(`{"kind":"OAuthRedirectReference","apiVersion":"v1","reference":{"kind":"Route","name":"%v"}}`, instance.Name)
+		err := n.platformService.AnnotateServiceAccount(instance, map[string]string{
+			"serviceaccounts.openshift.io/oauth-redirectreference.primary": redirectReference,
+		})
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to allow OAuth redirect for service account")
+		}
+		cookieSecretName, err := n.createCookieSecret(instance)
+		if err != nil {
+			return nil, 0, err
+		}
+		port = nexusDefaultSpec.NexusOAuth2ProxyPort
+		if len(image) == 0 {
+			image = nexusDefaultSpec.NexusOpenshiftOAuthProxyImage
+		}
+		args = append(args,
+			"--provider=openshift",
+			fmt.Sprintf("--openshift-service-account=%v", instance.Name),
+			"--email-domain=*",
+			"--skip-provider-button",
+			fmt.Sprintf("--http-address=0.0.0.0:%v", port),
+			"--https-address=",
+			fmt.Sprintf("--upstream=%v", getUpstreamUrl(instance)),
+		)
+		for _, group := range proxy.Roles {
+			args = append(args, fmt.Sprintf("--openshift-group=%v", group))
+		}
+		env = append(env, getSecretEnv("OAUTH2_PROXY_COOKIE_SECRET", cookieSecretName, nexusDefaultSpec.NexusAuthProxyCookieSecretKey))
+	default:
+		return nil, 0, fmt.Errorf("authentication proxy %v is not supported", proxy.Type)
+	}
+
+	return &coreV1Api.Container{
+		Name:            nexusDefaultSpec.NexusAuthProxyContainerName,
+		Image:           image,
+		ImagePullPolicy: coreV1Api.PullIfNotPresent,
+		Ports: []coreV1Api.ContainerPort{
+			{
+				ContainerPort: port,
+				Protocol:      coreV1Api.ProtocolTCP,
+			},
+		},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: coreV1Api.TerminationMessageReadFile,
+		Args:                     append(args, proxy.ExtraArgs...),
+		Env:                      env,
+	}, port, nil
+}
@@ -0,0 +1,211 @@
+package nexus
+
+import (
+	"context"
+	"fmt"
+	keycloakV1Api "github.com/epmd-edp/keycloak-operator/pkg/apis/v1/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"testing"
+)
+
+// oidcPlatform returns Keycloak client and route of Nexus and accepts Secrets, other methods of platform service are
+// not implemented
+type oidcPlatform struct {
+	secretPlatform
+	keycloakClient keycloakV1Api.KeycloakClient
+}
+
+func (p oidcPlatform) GetKeycloakClient(name string, namespace string) (keycloakV1Api.KeycloakClient, error) {
+	return p.keycloakClient, nil
+}
+
+func (p oidcPlatform) GetExternalUrl(namespace string, name string) (string, string, string, error) {
+	return "https://nexus-edp.example.com", "nexus-edp.example.com", "https", nil
+}
+
+func (p oidcPlatform) CreateSecret(instance v1alpha1.Nexus, name string, data map[string][]byte) error {
+	return nil
+}
+
+// keycloakGetter returns Keycloak realm and Keycloak CRs, other methods of client are not implemented
+type keycloakGetter struct {
+	client.Client
+	realm    keycloakV1Api.KeycloakRealm
+	keycloak keycloakV1Api.Keycloak
+}
+
+func (c keycloakGetter) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	switch o := obj.(type) {
+	case *keycloakV1Api.KeycloakRealm:
+		*o = c.realm
+	case *keycloakV1Api.Keycloak:
+		*o = c.keycloak
+	default:
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+	return nil
+}
+
+func TestGetAuthProxy(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy *v1alpha1.NexusAuthProxy
+		want  v1alpha1.NexusAuthProxy
+	}{
+		{
+			name: "gatekeeper with default roles if proxy is not set",
+			want: v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyGatekeeper, Roles: authProxyDefaultRoles},
+		},
+		{
+			name:  "default roles of oauth2-proxy",
+			proxy: &v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyOAuth2Proxy, Image: "oauth2-proxy:v7"},
+			want:  v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyOAuth2Proxy, Image: "oauth2-proxy:v7", Roles: authProxyDefaultRoles},
+		},
+		{
+			name:  "roles from spec",
+			proxy: &v1alpha1.NexusAuthProxy{Roles: []string{"nexus-users"}},
+			want:  v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyGatekeeper, Roles: []string{"nexus-users"}},
+		},
+		{
+			name:  "access through OpenShift oauth-proxy is not restricted by default",
+			proxy: &v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyOpenshiftOAuthProxy},
+			want:  v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyOpenshiftOAuthProxy},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getAuthProxy(v1alpha1.Nexus{Spec: v1alpha1.NexusSpec{AuthProxy: tt.proxy}}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getAuthProxy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetOidcSettings(t *testing.T) {
+	clientSecret := map[string]map[string][]byte{
+		"nexus-is-credentials": {nexusDefaultSpec.KeycloakClientSecretKey: []byte("secret")},
+	}
+	keycloakClient := keycloakV1Api.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: "edp",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "KeycloakRealm", Name: "main"}}},
+		Spec: keycloakV1Api.KeycloakClientSpec{ClientId: "nexus"},
+	}
+	realm := keycloakV1Api.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "edp",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Keycloak", Name: "main"}}},
+		Spec: keycloakV1Api.KeycloakRealmSpec{RealmName: "openshift"},
+	}
+	keycloak := keycloakV1Api.Keycloak{Spec: keycloakV1Api.KeycloakSpec{Url: "https://keycloak.example.com"}}
+
+	tests := []struct {
+		name           string
+		keycloakClient keycloakV1Api.KeycloakClient
+		secrets        map[string]map[string][]byte
+		want           *oidcSettings
+		wantErr        bool
+	}{
+		{
+			name:           "settings of Keycloak client",
+			keycloakClient: keycloakClient,
+			secrets:        clientSecret,
+			want: &oidcSettings{
+				clientId:         "nexus",
+				clientSecretName: "nexus-is-credentials",
+				issuerUrl:        "https://keycloak.example.com/auth/realms/openshift",
+				externalUrl:      "https://nexus-edp.example.com",
+			},
+		},
+		{
+			name:           "Keycloak client is not added to realm yet",
+			keycloakClient: keycloakV1Api.KeycloakClient{Spec: keycloakV1Api.KeycloakClientSpec{ClientId: "nexus"}},
+			secrets:        clientSecret,
+			wantErr:        true,
+		},
+		{
+			name:           "client secret is not generated yet",
+			keycloakClient: keycloakClient,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NexusServiceImpl{
+				platformService: oidcPlatform{secretPlatform: secretPlatform{secrets: tt.secrets}, keycloakClient: tt.keycloakClient},
+				k8sClient:       keycloakGetter{realm: realm, keycloak: keycloak},
+			}
+			instance := v1alpha1.Nexus{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: "edp"}}
+			got, err := n.getOidcSettings(instance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getOidcSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getOidcSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAuthProxyContainerOAuth2Proxy(t *testing.T) {
+	const skipVerifyArg = "--ssl-insecure-skip-verify=true"
+	tests := []struct {
+		name           string
+		proxy          v1alpha1.NexusAuthProxy
+		wantSkipVerify bool
+	}{
+		{
+			name:  "TLS certificate of Keycloak is verified by default",
+			proxy: v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyOAuth2Proxy},
+		},
+		{
+			name:           "verification of TLS certificate is disabled",
+			proxy:          v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyOAuth2Proxy, InsecureSkipVerify: true},
+			wantSkipVerify: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NexusServiceImpl{
+				platformService: oidcPlatform{
+					secretPlatform: secretPlatform{secrets: map[string]map[string][]byte{
+						"nexus-is-credentials": {nexusDefaultSpec.KeycloakClientSecretKey: []byte("secret")},
+					}},
+					keycloakClient: keycloakV1Api.KeycloakClient{
+						ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: "edp",
+							OwnerReferences: []metav1.OwnerReference{{Kind: "KeycloakRealm", Name: "main"}}},
+						Spec: keycloakV1Api.KeycloakClientSpec{ClientId: "nexus"},
+					},
+				},
+				k8sClient: keycloakGetter{
+					realm: keycloakV1Api.KeycloakRealm{
+						ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "edp",
+							OwnerReferences: []metav1.OwnerReference{{Kind: "Keycloak", Name: "main"}}},
+						Spec: keycloakV1Api.KeycloakRealmSpec{RealmName: "openshift"},
+					},
+					keycloak: keycloakV1Api.Keycloak{Spec: keycloakV1Api.KeycloakSpec{Url: "https://keycloak.example.com"}},
+				},
+			}
+			instance := v1alpha1.Nexus{
+				ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: "edp"},
+				Spec:       v1alpha1.NexusSpec{AuthProxy: &tt.proxy},
+			}
+			container, _, err := n.getAuthProxyContainer(instance)
+			if err != nil {
+				t.Fatalf("getAuthProxyContainer() error = %v", err)
+			}
+			skipVerify := false
+			for _, arg := range container.Args {
+				if arg == skipVerifyArg {
+					skipVerify = true
+				}
+			}
+			if skipVerify != tt.wantSkipVerify {
+				t.Errorf("getAuthProxyContainer() args = %v, %v is expected %v", container.Args, skipVerifyArg, tt.wantSkipVerify)
+			}
+		})
+	}
+}
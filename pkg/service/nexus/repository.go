@@ -0,0 +1,479 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	RepositoryTypeHosted = "hosted"
+	RepositoryTypeProxy  = "proxy"
+	RepositoryTypeGroup  = "group"
+)
+
+// supportedRepositoryFormats maps repository format to the list of types which can be created for it
+var supportedRepositoryFormats = map[string][]string{
+	"maven":  {RepositoryTypeHosted, RepositoryTypeProxy, RepositoryTypeGroup},
+	"npm":    {RepositoryTypeHosted, RepositoryTypeProxy, RepositoryTypeGroup},
+	"nuget":  {RepositoryTypeHosted, RepositoryTypeProxy, RepositoryTypeGroup},
+	"docker": {RepositoryTypeHosted, RepositoryTypeProxy, RepositoryTypeGroup},
+	"pypi":   {RepositoryTypeHosted, RepositoryTypeProxy, RepositoryTypeGroup},
+	"helm":   {RepositoryTypeHosted, RepositoryTypeProxy},
+	"raw":    {RepositoryTypeHosted, RepositoryTypeProxy, RepositoryTypeGroup},
+	"go":     {RepositoryTypeProxy, RepositoryTypeGroup},
+	"apt":    {RepositoryTypeHosted, RepositoryTypeProxy},
+	"yum":    {RepositoryTypeHosted, RepositoryTypeProxy, RepositoryTypeGroup},
+}
+
+func (n NexusServiceImpl) getNexusClient(instance v1alpha1.Nexus) (*nexus.NexusClient, error) {
+	u, err := n.getNexusRestApiUrl(instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get Nexus REST API URL")
+	}
+
+	nexusPassword, err := n.getNexusAdminPassword(instance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get Nexus admin password from secret")
+	}
+
+	nexusClient := &nexus.NexusClient{}
+	err = nexusClient.InitNewRestClient(&instance, u, nexusDefaultSpec.NexusDefaultAdminUser, nexusPassword)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize Nexus client")
+	}
+	return nexusClient, nil
+}
+
+// GetRepositoryName returns the name of the repository in Nexus for NexusRepository CR
+func GetRepositoryName(repository v1alpha1.NexusRepository) string {
+	if len(repository.Spec.Name) != 0 {
+		return repository.Spec.Name
+	}
+	return repository.Name
+}
+
+func validateRepository(repository v1alpha1.NexusRepository) error {
+	types, ok := supportedRepositoryFormats[repository.Spec.Format]
+	if !ok {
+		return fmt.Errorf("repository format %v is not supported", repository.Spec.Format)
+	}
+
+	typeIsSupported := false
+	for _, t := range types {
+		if t == repository.Spec.Type {
+			typeIsSupported = true
+		}
+	}
+	if !typeIsSupported {
+		return fmt.Errorf("repository type %v is not supported for %v format", repository.Spec.Type, repository.Spec.Format)
+	}
+
+	switch repository.Spec.Type {
+	case RepositoryTypeProxy:
+		if repository.Spec.Proxy == nil || len(repository.Spec.Proxy.RemoteUrl) == 0 {
+			return errors.New("remote URL is mandatory for proxy repository")
+		}
+	case RepositoryTypeGroup:
+		if repository.Spec.Group == nil || len(repository.Spec.Group.MemberNames) == 0 {
+			return errors.New("at least one member is mandatory for group repository")
+		}
+	}
+
+	if docker := repository.Spec.Docker; docker != nil {
+		if repository.Spec.Format != "docker" {
+			return fmt.Errorf("docker settings can not be set for %v format", repository.Spec.Format)
+		}
+		switch docker.HttpPort {
+		case nexusDefaultSpec.NexusPort, nexusDefaultSpec.NexusKeycloakProxyPort, nexusDefaultSpec.NexusOAuth2ProxyPort:
+			return fmt.Errorf("port %v is used by Nexus pod and can not be used by docker connector", docker.HttpPort)
+		}
+		if docker.IndexType == "CUSTOM" && len(docker.IndexUrl) == 0 {
+			return errors.New("index URL is mandatory for CUSTOM index type")
+		}
+	}
+	if len(repository.Spec.CleanupPolicies) != 0 && repository.Spec.Type == RepositoryTypeGroup {
+		return errors.New("cleanup policies can not be applied to group repository")
+	}
+	if repository.Spec.Raw != nil && repository.Spec.Format != "raw" {
+		return fmt.Errorf("raw settings can not be set for %v format", repository.Spec.Format)
+	}
+	if repository.Spec.Yum != nil && repository.Spec.Format != "yum" {
+		return fmt.Errorf("yum settings can not be set for %v format", repository.Spec.Format)
+	}
+	// Nexus signs metadata of proxy and group yum repositories only, hosted ones are signed by their clients
+	if repository.Spec.Yum != nil && len(repository.Spec.Yum.SigningSecret) != 0 && repository.Spec.Type == RepositoryTypeHosted {
+		return errors.New("signing Secret can not be set for hosted yum repository")
+	}
+	if repository.Spec.Apt != nil && repository.Spec.Format != "apt" {
+		return fmt.Errorf("apt settings can not be set for %v format", repository.Spec.Format)
+	}
+	if repository.Spec.Format == "apt" {
+		if repository.Spec.Apt == nil || len(repository.Spec.Apt.Distribution) == 0 {
+			return errors.New("distribution is mandatory for apt repository")
+		}
+		if repository.Spec.Type == RepositoryTypeHosted && len(repository.Spec.Apt.SigningSecret) == 0 {
+			return errors.New("signing Secret is mandatory for hosted apt repository")
+		}
+	}
+	return nil
+}
+
+// getRegistryEndpointName returns name of Ingress or Route of docker registry
+func getRegistryEndpointName(instance v1alpha1.Nexus, repository v1alpha1.NexusRepository) string {
+	return fmt.Sprintf("%v-%v", instance.Name, repository.Name)
+}
+
+// getRepositoryScriptParameters converts NexusRepository spec to the parameters of create-repo-* scripts
+func getRepositoryScriptParameters(repository v1alpha1.NexusRepository) map[string]interface{} {
+	blobStore := repository.Spec.BlobStore
+	if len(blobStore) == 0 {
+		blobStore = nexusDefaultSpec.NexusDefaultBlobStore
+	}
+	strictContentValidation := true
+	if repository.Spec.StrictContentValidation != nil {
+		strictContentValidation = *repository.Spec.StrictContentValidation
+	}
+	writePolicy := repository.Spec.WritePolicy
+	if len(writePolicy) == 0 {
+		writePolicy = nexusDefaultSpec.NexusDefaultWritePolicy
+	}
+	versionPolicy := repository.Spec.VersionPolicy
+	if len(versionPolicy) == 0 {
+		versionPolicy = nexusDefaultSpec.NexusDefaultVersionPolicy
+	}
+	layoutPolicy := repository.Spec.LayoutPolicy
+	if len(layoutPolicy) == 0 {
+		layoutPolicy = nexusDefaultSpec.NexusDefaultLayoutPolicy
+	}
+
+	parameters := map[string]interface{}{
+		"name":                      GetRepositoryName(repository),
+		"blob_store":                blobStore,
+		"strict_content_validation": strconv.FormatBool(strictContentValidation),
+		"write_policy":              writePolicy,
+		"version_policy":            versionPolicy,
+		"layout_policy":             layoutPolicy,
+	}
+	if repository.Spec.Proxy != nil {
+		parameters["remote_url"] = repository.Spec.Proxy.RemoteUrl
+	}
+	if repository.Spec.Type != RepositoryTypeGroup {
+		// Policies are always passed, so the ones removed from spec are detached from repository
+		cleanupPolicies := repository.Spec.CleanupPolicies
+		if cleanupPolicies == nil {
+			cleanupPolicies = []string{}
+		}
+		parameters["cleanup_policies"] = cleanupPolicies
+	}
+	if repository.Spec.Group != nil {
+		parameters["member_repos"] = repository.Spec.Group.MemberNames
+	}
+	if repository.Spec.Raw != nil && len(repository.Spec.Raw.ContentDisposition) != 0 {
+		parameters["content_disposition"] = repository.Spec.Raw.ContentDisposition
+	}
+	if apt := repository.Spec.Apt; apt != nil {
+		parameters["distribution"] = apt.Distribution
+		parameters["flat"] = strconv.FormatBool(apt.Flat)
+	}
+	if yum := repository.Spec.Yum; yum != nil {
+		parameters["repodata_depth"] = strconv.Itoa(int(yum.RepodataDepth))
+		if len(yum.DeployPolicy) != 0 {
+			parameters["deploy_policy"] = yum.DeployPolicy
+		}
+	}
+	if repository.Spec.Format == "docker" {
+		docker := v1alpha1.NexusRepositoryDocker{}
+		if repository.Spec.Docker != nil {
+			docker = *repository.Spec.Docker
+		}
+		forceBasicAuth := true
+		if docker.ForceBasicAuth != nil {
+			forceBasicAuth = *docker.ForceBasicAuth
+		}
+		if docker.HttpPort != 0 {
+			parameters["http_port"] = strconv.Itoa(int(docker.HttpPort))
+		}
+		parameters["force_basic_auth"] = strconv.FormatBool(forceBasicAuth)
+		parameters["v1_enabled"] = strconv.FormatBool(docker.V1Enabled)
+		if repository.Spec.Type == RepositoryTypeProxy {
+			indexType := docker.IndexType
+			if len(indexType) == 0 {
+				indexType = "REGISTRY"
+				if strings.Contains(repository.Spec.Proxy.RemoteUrl, nexusDefaultSpec.DockerHubRegistryHost) {
+					indexType = "HUB"
+				}
+			}
+			parameters["index_type"] = indexType
+			parameters["index_url"] = docker.IndexUrl
+		}
+	}
+	return parameters
+}
+
+// newRepositoryApiModel converts parameters of create-repo-* scripts to the model of repositories REST API
+func newRepositoryApiModel(format string, repositoryType string, parameters map[string]interface{}) nexus.Repository {
+	repository := nexus.Repository{
+		Format: format,
+		Type:   repositoryType,
+		Name:   getStringParameter(parameters, "name"),
+		Online: true,
+		Storage: &nexus.RepositoryStorage{
+			BlobStoreName:               getStringParameter(parameters, "blob_store"),
+			StrictContentTypeValidation: getStringParameter(parameters, "strict_content_validation") != "false",
+		},
+	}
+
+	// Cleanup policies of repositories from ConfigMap are kept as is unless they are set explicitly
+	if _, ok := parameters["cleanup_policies"]; ok && repositoryType != RepositoryTypeGroup {
+		repository.Cleanup = &nexus.RepositoryCleanup{PolicyNames: getStringSliceParameter(parameters, "cleanup_policies")}
+	}
+
+	switch repositoryType {
+	case RepositoryTypeHosted:
+		repository.Storage.WritePolicy = strings.ToLower(getStringParameter(parameters, "write_policy"))
+	case RepositoryTypeProxy:
+		repository.Proxy = &nexus.RepositoryProxy{
+			RemoteUrl:      getStringParameter(parameters, "remote_url"),
+			ContentMaxAge:  1440,
+			MetadataMaxAge: 1440,
+		}
+		repository.NegativeCache = &nexus.RepositoryNegativeCache{Enabled: true, TimeToLive: 1440}
+		repository.HttpClient = &nexus.RepositoryHttpClient{Blocked: false, AutoBlock: true}
+	case RepositoryTypeGroup:
+		repository.Group = &nexus.RepositoryGroup{MemberNames: getStringSliceParameter(parameters, "member_repos")}
+	}
+
+	if format == "maven" && repositoryType != RepositoryTypeGroup {
+		repository.Maven = &nexus.RepositoryMaven{
+			VersionPolicy: strings.ToUpper(getStringParameter(parameters, "version_policy")),
+			LayoutPolicy:  strings.ToUpper(getStringParameter(parameters, "layout_policy")),
+		}
+	}
+	if format == "docker" {
+		repository.Docker = &nexus.RepositoryDocker{
+			V1Enabled:      getStringParameter(parameters, "v1_enabled") == "true",
+			ForceBasicAuth: getStringParameter(parameters, "force_basic_auth") != "false",
+		}
+		if port, err := strconv.Atoi(getStringParameter(parameters, "http_port")); err == nil {
+			httpPort := int32(port)
+			repository.Docker.HttpPort = &httpPort
+		}
+		if repositoryType == RepositoryTypeProxy {
+			repository.DockerProxy = &nexus.RepositoryDockerProxy{
+				IndexType: getStringParameter(parameters, "index_type"),
+				IndexUrl:  getStringParameter(parameters, "index_url"),
+			}
+		}
+	}
+	if format == "raw" && repositoryType != RepositoryTypeGroup {
+		contentDisposition := strings.ToUpper(getStringParameter(parameters, "content_disposition"))
+		if len(contentDisposition) == 0 {
+			contentDisposition = nexusDefaultSpec.NexusDefaultRawContentDisposition
+		}
+		repository.Raw = &nexus.RepositoryRaw{ContentDisposition: contentDisposition}
+	}
+	var signing *nexus.RepositorySigning
+	if keypair := getStringParameter(parameters, "keypair"); len(keypair) != 0 {
+		signing = &nexus.RepositorySigning{Keypair: keypair, Passphrase: getStringParameter(parameters, "passphrase")}
+	}
+	if format == "apt" {
+		repository.Apt = &nexus.RepositoryApt{Distribution: getStringParameter(parameters, "distribution")}
+		if repositoryType == RepositoryTypeProxy {
+			flat := getStringParameter(parameters, "flat") == "true"
+			repository.Apt.Flat = &flat
+		}
+		repository.AptSigning = signing
+	}
+	if format == "yum" {
+		if repositoryType == RepositoryTypeHosted {
+			depth, _ := strconv.Atoi(getStringParameter(parameters, "repodata_depth"))
+			deployPolicy := strings.ToUpper(getStringParameter(parameters, "deploy_policy"))
+			if len(deployPolicy) == 0 {
+				deployPolicy = nexusDefaultSpec.NexusDefaultYumDeployPolicy
+			}
+			repository.Yum = &nexus.RepositoryYum{RepodataDepth: int32(depth), DeployPolicy: deployPolicy}
+		} else {
+			repository.YumSigning = signing
+		}
+	}
+	if format == "nuget" && repositoryType == RepositoryTypeProxy {
+		nugetVersion := "V2"
+		if strings.Contains(repository.Proxy.RemoteUrl, "/v3/") {
+			nugetVersion = "V3"
+		}
+		repository.NugetProxy = &nexus.RepositoryNugetProxy{QueryCacheItemMaxAge: 3600, NugetVersion: nugetVersion}
+	}
+	return repository
+}
+
+// scriptRepositoryFormats are formats which have create-repo-<format>-<type> scripts for Nexus without repositories API
+var scriptRepositoryFormats = []string{"docker", "maven", "npm", "nuget"}
+
+// createOrUpdateRepository creates or updates repository through REST API or runs create-repo-<format>-<type> script
+// if the API is not supported
+func (n NexusServiceImpl) createOrUpdateRepository(nexusClient *nexus.NexusClient, format string, repositoryType string, parameters map[string]interface{}) error {
+	supported, err := nexusClient.IsApiSupported(nexus.RepositoriesApi)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if repositories REST API is supported")
+	}
+	if !supported {
+		if !controllerHelper.ContainsString(scriptRepositoryFormats, format) {
+			return fmt.Errorf("format %v requires the repositories REST API (Nexus >= %v)", format, nexus.RepositoriesApiVersion)
+		}
+		return runFallbackScript(nexusClient, nexus.RepositoriesApi, fmt.Sprintf("create-repo-%v-%v", format, repositoryType), parameters)
+	}
+
+	repository := newRepositoryApiModel(format, repositoryType, parameters)
+	existing, err := nexusClient.GetRepository(repository.Name)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nexusClient.CreateRepository(repository)
+	}
+	if existing.Type != repositoryType {
+		return fmt.Errorf("repository %v already exists with %v type", repository.Name, existing.Type)
+	}
+	return nexusClient.UpdateRepository(repository)
+}
+
+// deleteRepository deletes repository through REST API or runs delete-repo script if the API is not supported
+func (n NexusServiceImpl) deleteRepository(nexusClient *nexus.NexusClient, name string) error {
+	err := nexusClient.DeleteRepository(name)
+	if nexus.IsErrNotFound(err) {
+		existing, getErr := nexusClient.GetRepository(name)
+		if getErr != nil {
+			return getErr
+		}
+		if existing == nil {
+			return nil
+		}
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.RepositoriesApi, err, "delete-repo", map[string]interface{}{"name": name})
+}
+
+// CreateOrUpdateRepository creates repository described by NexusRepository CR or updates the existing one
+func (n NexusServiceImpl) CreateOrUpdateRepository(instance v1alpha1.Nexus, repository v1alpha1.NexusRepository) error {
+	if err := validateRepository(repository); err != nil {
+		return errors.Wrapf(err, "repository %v is invalid", repository.Name)
+	}
+
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return err
+	}
+
+	parameters := getRepositoryScriptParameters(repository)
+	if err := n.setSigningParameters(repository, parameters); err != nil {
+		return err
+	}
+
+	repositoryName := GetRepositoryName(repository)
+	err = n.createOrUpdateRepository(nexusClient, repository.Spec.Format, repository.Spec.Type, parameters)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create repository %v", repositoryName)
+	}
+
+	exists, err := nexusClient.CheckRepositoryExist(repositoryName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("repository %v is not present in Nexus after creation", repositoryName)
+	}
+
+	if repository.Spec.Format == "docker" {
+		return n.setupRegistry(instance, repository, nexusClient)
+	}
+	return nil
+}
+
+// GetSigningSecretName returns name of Secret with GPG keypair of apt or yum repository or empty string if it is not set
+func GetSigningSecretName(repository v1alpha1.NexusRepository) string {
+	if repository.Spec.Apt != nil {
+		return repository.Spec.Apt.SigningSecret
+	}
+	if repository.Spec.Yum != nil {
+		return repository.Spec.Yum.SigningSecret
+	}
+	return ""
+}
+
+// setSigningParameters adds GPG keypair and passphrase from signing Secret to repository parameters
+func (n NexusServiceImpl) setSigningParameters(repository v1alpha1.NexusRepository, parameters map[string]interface{}) error {
+	secretName := GetSigningSecretName(repository)
+	if len(secretName) == 0 {
+		return nil
+	}
+	data, err := n.platformService.GetSecretData(repository.Namespace, secretName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get signing Secret %v", secretName)
+	}
+	keypair, ok := data[nexusDefaultSpec.SigningSecretKeypairKey]
+	if !ok {
+		return fmt.Errorf("key %v is not found in signing Secret %v", nexusDefaultSpec.SigningSecretKeypairKey, secretName)
+	}
+	parameters["keypair"] = string(keypair)
+	parameters["passphrase"] = string(data[nexusDefaultSpec.SigningSecretPassphraseKey])
+	return nil
+}
+
+// setupRegistry enables authentication of docker clients and exposes connector of docker repository
+// or removes the exposed connector if port is not set
+func (n NexusServiceImpl) setupRegistry(instance v1alpha1.Nexus, repository v1alpha1.NexusRepository, nexusClient *nexus.NexusClient) error {
+	if len(instance.Spec.Realms) == 0 {
+		if err := n.enableRealm(nexusClient, nexusDefaultSpec.NexusDockerTokenRealm); err != nil {
+			return errors.Wrapf(err, "failed to enable %v realm", nexusDefaultSpec.NexusDockerTokenRealm)
+		}
+	} else if !controllerHelper.ContainsString(instance.Spec.Realms, nexusDefaultSpec.NexusDockerTokenRealm) {
+		// Realms which are not listed in spec are deactivated by Nexus controller
+		return fmt.Errorf("%v realm has to be listed in realms of Nexus %v", nexusDefaultSpec.NexusDockerTokenRealm, instance.Name)
+	}
+
+	name := getRegistryEndpointName(instance, repository)
+	if repository.Spec.Docker == nil || repository.Spec.Docker.HttpPort == 0 {
+		return n.platformService.RemoveRegistry(instance, name)
+	}
+	host := repository.Spec.Docker.Host
+	if len(host) == 0 {
+		host = fmt.Sprintf("%v-%v.%v", name, instance.Namespace, instance.Spec.EdpSpec.DnsWildcard)
+	}
+	if err := n.platformService.ExposeRegistry(instance, name, host, repository.Spec.Docker.HttpPort); err != nil {
+		return errors.Wrapf(err, "failed to expose registry of repository %v", GetRepositoryName(repository))
+	}
+	return nil
+}
+
+// DeleteRepository deletes repository described by NexusRepository CR from Nexus
+func (n NexusServiceImpl) DeleteRepository(instance v1alpha1.Nexus, repository v1alpha1.NexusRepository) error {
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return err
+	}
+
+	repositoryName := GetRepositoryName(repository)
+	if err := n.deleteRepository(nexusClient, repositoryName); err != nil {
+		return errors.Wrapf(err, "failed to delete repository %v", repositoryName)
+	}
+
+	exists, err := nexusClient.CheckRepositoryExist(repositoryName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("repository %v is still present in Nexus after deletion", repositoryName)
+	}
+
+	if repository.Spec.Format == "docker" {
+		if err := n.platformService.RemoveRegistry(instance, getRegistryEndpointName(instance, repository)); err != nil {
+			return errors.Wrapf(err, "failed to remove registry of repository %v", repositoryName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestValidateRepository(t *testing.T) {
+	proxy := &v1alpha1.NexusRepositoryProxy{RemoteUrl: "https://repo1.maven.org/maven2/"}
+	group := &v1alpha1.NexusRepositoryGroup{MemberNames: []string{"maven-releases"}}
+	tests := []struct {
+		name    string
+		spec    v1alpha1.NexusRepositorySpec
+		wantErr bool
+	}{
+		{name: "hosted maven", spec: v1alpha1.NexusRepositorySpec{Format: "maven", Type: RepositoryTypeHosted}},
+		{name: "proxy maven", spec: v1alpha1.NexusRepositorySpec{Format: "maven", Type: RepositoryTypeProxy, Proxy: proxy}},
+		{name: "group maven", spec: v1alpha1.NexusRepositorySpec{Format: "maven", Type: RepositoryTypeGroup, Group: group}},
+		{name: "unsupported format", spec: v1alpha1.NexusRepositorySpec{Format: "conan", Type: RepositoryTypeHosted}, wantErr: true},
+		{name: "unsupported type", spec: v1alpha1.NexusRepositorySpec{Format: "maven", Type: "snapshot"}, wantErr: true},
+		{name: "unsupported type of format", spec: v1alpha1.NexusRepositorySpec{Format: "go", Type: RepositoryTypeHosted}, wantErr: true},
+		{name: "proxy without remote URL", spec: v1alpha1.NexusRepositorySpec{Format: "npm", Type: RepositoryTypeProxy}, wantErr: true},
+		{name: "group without members", spec: v1alpha1.NexusRepositorySpec{Format: "npm", Type: RepositoryTypeGroup,
+			Group: &v1alpha1.NexusRepositoryGroup{}}, wantErr: true},
+		{name: "docker settings of maven", spec: v1alpha1.NexusRepositorySpec{Format: "maven", Type: RepositoryTypeHosted,
+			Docker: &v1alpha1.NexusRepositoryDocker{}}, wantErr: true},
+		{name: "docker connector on Nexus port", spec: v1alpha1.NexusRepositorySpec{Format: "docker", Type: RepositoryTypeHosted,
+			Docker: &v1alpha1.NexusRepositoryDocker{HttpPort: nexusDefaultSpec.NexusPort}}, wantErr: true},
+		{name: "docker connector", spec: v1alpha1.NexusRepositorySpec{Format: "docker", Type: RepositoryTypeHosted,
+			Docker: &v1alpha1.NexusRepositoryDocker{HttpPort: 5000}}},
+		{name: "custom docker index without URL", spec: v1alpha1.NexusRepositorySpec{Format: "docker", Type: RepositoryTypeProxy,
+			Proxy: proxy, Docker: &v1alpha1.NexusRepositoryDocker{IndexType: "CUSTOM"}}, wantErr: true},
+		{name: "raw settings of npm", spec: v1alpha1.NexusRepositorySpec{Format: "npm", Type: RepositoryTypeHosted,
+			Raw: &v1alpha1.NexusRepositoryRaw{}}, wantErr: true},
+		{name: "cleanup policies of group", spec: v1alpha1.NexusRepositorySpec{Format: "maven", Type: RepositoryTypeGroup,
+			Group: group, CleanupPolicies: []string{"snapshots"}}, wantErr: true},
+		{name: "apt without distribution", spec: v1alpha1.NexusRepositorySpec{Format: "apt", Type: RepositoryTypeProxy,
+			Proxy: proxy}, wantErr: true},
+		{name: "hosted apt without signing Secret", spec: v1alpha1.NexusRepositorySpec{Format: "apt", Type: RepositoryTypeHosted,
+			Apt: &v1alpha1.NexusRepositoryApt{Distribution: "bionic"}}, wantErr: true},
+		{name: "hosted apt", spec: v1alpha1.NexusRepositorySpec{Format: "apt", Type: RepositoryTypeHosted,
+			Apt: &v1alpha1.NexusRepositoryApt{Distribution: "bionic", SigningSecret: "apt-signing"}}},
+		{name: "yum settings of apt", spec: v1alpha1.NexusRepositorySpec{Format: "apt", Type: RepositoryTypeProxy, Proxy: proxy,
+			Apt: &v1alpha1.NexusRepositoryApt{Distribution: "bionic"}, Yum: &v1alpha1.NexusRepositoryYum{}}, wantErr: true},
+		{name: "signing Secret of hosted yum", spec: v1alpha1.NexusRepositorySpec{Format: "yum", Type: RepositoryTypeHosted,
+			Yum: &v1alpha1.NexusRepositoryYum{SigningSecret: "yum-signing"}}, wantErr: true},
+		{name: "signed proxy yum", spec: v1alpha1.NexusRepositorySpec{Format: "yum", Type: RepositoryTypeProxy, Proxy: proxy,
+			Yum: &v1alpha1.NexusRepositoryYum{SigningSecret: "yum-signing"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRepository(v1alpha1.NexusRepository{Spec: tt.spec})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRepository() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRepositoryApiModel(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         string
+		repositoryType string
+		parameters     map[string]interface{}
+		check          func(t *testing.T, repository nexus.Repository)
+	}{
+		{
+			name:           "hosted maven",
+			format:         "maven",
+			repositoryType: RepositoryTypeHosted,
+			parameters: map[string]interface{}{
+				"name":                      "maven-releases",
+				"blob_store":                "default",
+				"strict_content_validation": "false",
+				"write_policy":              "allow_once",
+				"version_policy":            "release",
+				"layout_policy":             "strict",
+			},
+			check: func(t *testing.T, repository nexus.Repository) {
+				want := nexus.RepositoryStorage{BlobStoreName: "default", WritePolicy: "allow_once"}
+				if repository.Storage == nil || *repository.Storage != want {
+					t.Errorf("storage = %+v, want %+v", repository.Storage, want)
+				}
+				if repository.Maven == nil || repository.Maven.VersionPolicy != "RELEASE" || repository.Maven.LayoutPolicy != "STRICT" {
+					t.Errorf("maven = %+v, want RELEASE and STRICT policies", repository.Maven)
+				}
+				if repository.Cleanup != nil {
+					t.Errorf("cleanup = %+v, want cleanup policies to be kept", repository.Cleanup)
+				}
+			},
+		},
+		{
+			name:           "cleanup policies are set explicitly",
+			format:         "npm",
+			repositoryType: RepositoryTypeHosted,
+			parameters:     map[string]interface{}{"name": "npm-hosted", "cleanup_policies": []string{}},
+			check: func(t *testing.T, repository nexus.Repository) {
+				if repository.Cleanup == nil || len(repository.Cleanup.PolicyNames) != 0 {
+					t.Errorf("cleanup = %+v, want empty list of policies", repository.Cleanup)
+				}
+			},
+		},
+		{
+			name:           "group npm",
+			format:         "npm",
+			repositoryType: RepositoryTypeGroup,
+			parameters: map[string]interface{}{
+				"name":             "npm-all",
+				"member_repos":     []interface{}{"npm-hosted", "npm-proxy"},
+				"cleanup_policies": []string{"old"},
+			},
+			check: func(t *testing.T, repository nexus.Repository) {
+				if repository.Group == nil || !reflect.DeepEqual(repository.Group.MemberNames, []string{"npm-hosted", "npm-proxy"}) {
+					t.Errorf("group = %+v, want npm-hosted and npm-proxy members", repository.Group)
+				}
+				if repository.Cleanup != nil {
+					t.Errorf("cleanup = %+v, want no cleanup for group", repository.Cleanup)
+				}
+			},
+		},
+		{
+			name:           "proxy docker of Docker Hub",
+			format:         "docker",
+			repositoryType: RepositoryTypeProxy,
+			parameters: map[string]interface{}{
+				"name":       "docker-hub",
+				"remote_url": "https://registry-1.docker.io",
+				"http_port":  "5000",
+				"index_type": "HUB",
+			},
+			check: func(t *testing.T, repository nexus.Repository) {
+				if repository.Docker == nil || repository.Docker.HttpPort == nil || *repository.Docker.HttpPort != 5000 {
+					t.Errorf("docker = %+v, want HTTP port 5000", repository.Docker)
+				}
+				if repository.Docker != nil && !repository.Docker.ForceBasicAuth {
+					t.Error("force basic auth is disabled, want it to be enabled by default")
+				}
+				if repository.DockerProxy == nil || repository.DockerProxy.IndexType != "HUB" {
+					t.Errorf("docker proxy = %+v, want HUB index", repository.DockerProxy)
+				}
+				if repository.Proxy == nil || repository.Proxy.RemoteUrl != "https://registry-1.docker.io" {
+					t.Errorf("proxy = %+v, want Docker Hub remote URL", repository.Proxy)
+				}
+			},
+		},
+		{
+			name:           "hosted raw with default content disposition",
+			format:         "raw",
+			repositoryType: RepositoryTypeHosted,
+			parameters:     map[string]interface{}{"name": "files"},
+			check: func(t *testing.T, repository nexus.Repository) {
+				if repository.Raw == nil || repository.Raw.ContentDisposition != nexusDefaultSpec.NexusDefaultRawContentDisposition {
+					t.Errorf("raw = %+v, want default content disposition", repository.Raw)
+				}
+			},
+		},
+		{
+			name:           "proxy apt",
+			format:         "apt",
+			repositoryType: RepositoryTypeProxy,
+			parameters:     map[string]interface{}{"name": "ubuntu", "distribution": "bionic", "flat": "true"},
+			check: func(t *testing.T, repository nexus.Repository) {
+				if repository.Apt == nil || repository.Apt.Distribution != "bionic" || repository.Apt.Flat == nil || !*repository.Apt.Flat {
+					t.Errorf("apt = %+v, want flat bionic distribution", repository.Apt)
+				}
+				if repository.AptSigning != nil {
+					t.Errorf("apt signing = %+v, want no signing without keypair", repository.AptSigning)
+				}
+			},
+		},
+		{
+			name:           "hosted yum with default deploy policy",
+			format:         "yum",
+			repositoryType: RepositoryTypeHosted,
+			parameters:     map[string]interface{}{"name": "rpm", "repodata_depth": "2"},
+			check: func(t *testing.T, repository nexus.Repository) {
+				want := nexus.RepositoryYum{RepodataDepth: 2, DeployPolicy: nexusDefaultSpec.NexusDefaultYumDeployPolicy}
+				if repository.Yum == nil || *repository.Yum != want {
+					t.Errorf("yum = %+v, want %+v", repository.Yum, want)
+				}
+			},
+		},
+		{
+			name:           "signed proxy yum",
+			format:         "yum",
+			repositoryType: RepositoryTypeProxy,
+			parameters:     map[string]interface{}{"name": "centos", "keypair": "key", "passphrase": "secret"},
+			check: func(t *testing.T, repository nexus.Repository) {
+				want := nexus.RepositorySigning{Keypair: "key", Passphrase: "secret"}
+				if repository.YumSigning == nil || *repository.YumSigning != want {
+					t.Errorf("yum signing = %+v, want %+v", repository.YumSigning, want)
+				}
+			},
+		},
+		{
+			name:           "proxy nuget of V3 feed",
+			format:         "nuget",
+			repositoryType: RepositoryTypeProxy,
+			parameters:     map[string]interface{}{"name": "nuget.org", "remote_url": "https://api.nuget.org/v3/index.json"},
+			check: func(t *testing.T, repository nexus.Repository) {
+				if repository.NugetProxy == nil || repository.NugetProxy.NugetVersion != "V3" {
+					t.Errorf("nuget proxy = %+v, want V3", repository.NugetProxy)
+				}
+				if repository.Proxy == nil || repository.Proxy.RemoteUrl != "https://api.nuget.org/v3/index.json" {
+					t.Errorf("proxy = %+v, want nuget.org remote URL", repository.Proxy)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newRepositoryApiModel(tt.format, tt.repositoryType, tt.parameters)
+			if repository.Format != tt.format || repository.Type != tt.repositoryType || !repository.Online {
+				t.Errorf("newRepositoryApiModel() = %v/%v online %v, want online %v/%v",
+					repository.Format, repository.Type, repository.Online, tt.format, tt.repositoryType)
+			}
+			if repository.Name != tt.parameters["name"] {
+				t.Errorf("newRepositoryApiModel() name = %v, want %v", repository.Name, tt.parameters["name"])
+			}
+			tt.check(t, repository)
+		})
+	}
+}
+
+func TestSetSigningParameters(t *testing.T) {
+	secrets := map[string]map[string][]byte{
+		"apt-signing": {nexusDefaultSpec.SigningSecretKeypairKey: []byte("key"), nexusDefaultSpec.SigningSecretPassphraseKey: []byte("secret")},
+		"yum-signing": {nexusDefaultSpec.SigningSecretPassphraseKey: []byte("secret")},
+	}
+	tests := []struct {
+		name           string
+		spec           v1alpha1.NexusRepositorySpec
+		wantParameters map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:           "repository is not signed",
+			spec:           v1alpha1.NexusRepositorySpec{Format: "maven"},
+			wantParameters: map[string]interface{}{},
+		},
+		{
+			name:           "keypair and passphrase from Secret",
+			spec:           v1alpha1.NexusRepositorySpec{Format: "apt", Apt: &v1alpha1.NexusRepositoryApt{SigningSecret: "apt-signing"}},
+			wantParameters: map[string]interface{}{"keypair": "key", "passphrase": "secret"},
+		},
+		{
+			name:           "keypair is not found in Secret",
+			spec:           v1alpha1.NexusRepositorySpec{Format: "yum", Yum: &v1alpha1.NexusRepositoryYum{SigningSecret: "yum-signing"}},
+			wantParameters: map[string]interface{}{},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parameters := map[string]interface{}{}
+			n := NexusServiceImpl{platformService: secretPlatform{secrets: secrets}}
+			err := n.setSigningParameters(v1alpha1.NexusRepository{Spec: tt.spec}, parameters)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setSigningParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(parameters, tt.wantParameters) {
+				t.Errorf("setSigningParameters() parameters = %v, want %v", parameters, tt.wantParameters)
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdateRepository(t *testing.T) {
+	tests := []struct {
+		name         string
+		version      string
+		format       string
+		wantRequests []string
+		wantErr      bool
+	}{
+		{
+			name:         "repositories API",
+			version:      "3.29.0-02",
+			format:       "raw",
+			wantRequests: []string{"GET /repositories", "POST /repositories/raw/hosted"},
+		},
+		{
+			name:         "script of Nexus without repositories API",
+			version:      "3.20.1-01",
+			format:       "maven",
+			wantRequests: []string{"POST /script/create-repo-maven-hosted/run"},
+		},
+		{name: "format without script", version: "3.20.1-01", format: "raw", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/status" {
+					w.Header().Set("Server", fmt.Sprintf("Nexus/%v (OSS)", tt.version))
+					return
+				}
+				requests = append(requests, fmt.Sprintf("%v %v", r.Method, r.URL.Path))
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte("[]"))
+				}
+			}))
+			defer server.Close()
+			nexusClient := &nexus.NexusClient{}
+			_ = nexusClient.InitNewRestClient(&v1alpha1.Nexus{}, server.URL, "admin", "admin")
+
+			err := (NexusServiceImpl{}).createOrUpdateRepository(nexusClient, tt.format, RepositoryTypeHosted,
+				map[string]interface{}{"name": "releases"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createOrUpdateRepository() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("createOrUpdateRepository() requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
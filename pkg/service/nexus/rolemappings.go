@@ -0,0 +1,108 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+// roleMappingsAnnotationSuffix - annotation on Nexus CR which keeps ids of roles created for Keycloak role mappings
+const roleMappingsAnnotationSuffix = "role-mappings"
+
+// validateRoleMappings checks prerequisites of role mappings: users have to be authenticated by rutauth-realm and
+// Keycloak roles have to be passed to Nexus, only gatekeeper passes them in X-Auth-Roles header.
+// Mapping creates Nexus role with id of Keycloak role, so it can't use id of reserved role managed otherwise
+func validateRoleMappings(instance v1alpha1.Nexus, reserved []string) error {
+	if proxyType := getAuthProxy(instance).Type; proxyType != v1alpha1.AuthProxyGatekeeper {
+		return fmt.Errorf("Keycloak role mappings require %v authentication proxy, %v doesn't pass roles to Nexus",
+			v1alpha1.AuthProxyGatekeeper, proxyType)
+	}
+	if len(instance.Spec.Realms) != 0 && !controllerHelper.ContainsString(instance.Spec.Realms, nexusDefaultSpec.NexusRutAuthRealm) {
+		return fmt.Errorf("Keycloak role mappings require %v in realms", nexusDefaultSpec.NexusRutAuthRealm)
+	}
+	var declared []string
+	for _, mapping := range instance.Spec.KeycloakSpec.RoleMappings {
+		if len(mapping.KeycloakRole) == 0 {
+			return errors.New("Keycloak role of role mapping is not set")
+		}
+		if controllerHelper.ContainsString(reserved, mapping.KeycloakRole) {
+			return fmt.Errorf("Keycloak role %v can't be mapped, Nexus role with the same id is managed by operator",
+				mapping.KeycloakRole)
+		}
+		if controllerHelper.ContainsString(declared, mapping.KeycloakRole) {
+			return fmt.Errorf("Keycloak role %v is mapped more than once", mapping.KeycloakRole)
+		}
+		declared = append(declared, mapping.KeycloakRole)
+	}
+	return nil
+}
+
+// getReservedRoles returns ids of Nexus roles which can't be used by role mappings: built-in roles, roles from
+// default-roles ConfigMap and roles created from it before
+func (n NexusServiceImpl) getReservedRoles(instance v1alpha1.Nexus) ([]string, error) {
+	roles, err := n.getDefaultRoles(instance)
+	if err != nil {
+		return nil, err
+	}
+	reserved := append([]string{}, protectedRoles...)
+	for _, role := range roles {
+		reserved = append(reserved, getStringParameter(role, "id"))
+	}
+	return append(reserved, getCreatedNames(instance, rolesAnnotationSuffix)...), nil
+}
+
+// syncRoleMappings creates or updates Nexus roles which map Keycloak roles to Nexus roles and deletes mappings
+// which have been removed from spec
+func (n NexusServiceImpl) syncRoleMappings(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient) error {
+	reserved, err := n.getReservedRoles(*instance)
+	if err != nil {
+		return err
+	}
+	if len(instance.Spec.KeycloakSpec.RoleMappings) != 0 {
+		if err := validateRoleMappings(*instance, reserved); err != nil {
+			return err
+		}
+		// Realms from spec are activated in Configure, the realm is enabled here only if they are not set
+		if len(instance.Spec.Realms) == 0 {
+			if err := n.enableRealm(nexusClient, nexusDefaultSpec.NexusRutAuthRealm); err != nil {
+				return errors.Wrapf(err, "failed to enable %v realm", nexusDefaultSpec.NexusRutAuthRealm)
+			}
+		}
+	}
+
+	mapped := getCreatedNames(*instance, roleMappingsAnnotationSuffix)
+	var declared []string
+	for _, mapping := range instance.Spec.KeycloakSpec.RoleMappings {
+		parameters := map[string]interface{}{
+			"id":          mapping.KeycloakRole,
+			"name":        mapping.KeycloakRole,
+			"description": fmt.Sprintf("Mapping of Keycloak role %v", mapping.KeycloakRole),
+			"privileges":  []string{},
+			"roles":       mapping.NexusRoles,
+		}
+		if err := n.setupRole(nexusClient, parameters); err != nil {
+			return errors.Wrapf(err, "failed to map Keycloak role %v", mapping.KeycloakRole)
+		}
+		declared = append(declared, mapping.KeycloakRole)
+	}
+
+	for _, id := range mapped {
+		// Reserved role could be mapped before mappings were validated, it is kept but not tracked anymore
+		if controllerHelper.ContainsString(declared, id) || controllerHelper.ContainsString(reserved, id) {
+			continue
+		}
+		if err := nexusClient.DeleteRole(id); err != nil && !nexus.IsErrNotFound(err) {
+			return errors.Wrapf(err, "failed to delete mapping of Keycloak role %v", id)
+		}
+		log.Info("Role mapping has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "Role", id)
+	}
+
+	if reflect.DeepEqual(mapped, declared) {
+		return nil
+	}
+	return n.setTrackedValue(instance, roleMappingsAnnotationSuffix, declared)
+}
@@ -0,0 +1,229 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// roleServer emulates roles API of Nexus and records requests which change roles
+type roleServer struct {
+	mu       sync.Mutex
+	roles    map[string]nexus.Role
+	requests []string
+}
+
+func (s *roleServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := strings.TrimPrefix(r.URL.Path, "/security/roles/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/security/roles":
+		list := make([]nexus.Role, 0, len(s.roles))
+		for _, role := range s.roles {
+			list = append(list, role)
+		}
+		_ = json.NewEncoder(w).Encode(list)
+		return
+	case r.Method == http.MethodGet:
+		role, ok := s.roles[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(role)
+		return
+	case r.Method == http.MethodPost || r.Method == http.MethodPut:
+		var role nexus.Role
+		if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.roles[role.Id] = role
+		id = role.Id
+	case r.Method == http.MethodDelete:
+		if _, ok := s.roles[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.roles, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.requests = append(s.requests, fmt.Sprintf("%v %v", r.Method, id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// configMapPlatform returns data of ConfigMaps from the map, other methods of platform service are not implemented
+type configMapPlatform struct {
+	platform.PlatformService
+	configMaps map[string]map[string]string
+}
+
+func (p configMapPlatform) GetConfigMapData(namespace string, name string) (map[string]string, error) {
+	return p.configMaps[name], nil
+}
+
+func TestSyncRoleMappings(t *testing.T) {
+	annotationKey := helper.GenerateAnnotationKey(roleMappingsAnnotationSuffix)
+	mapping := v1alpha1.KeycloakRoleMapping{KeycloakRole: "nexus-admins", NexusRoles: []string{"nx-admin"}}
+
+	tests := []struct {
+		name         string
+		existing     []string
+		mapped       string
+		mappings     []v1alpha1.KeycloakRoleMapping
+		wantRequests []string
+		wantMapped   []string
+		wantErr      bool
+	}{
+		{
+			name:         "mapping is created",
+			mappings:     []v1alpha1.KeycloakRoleMapping{mapping},
+			wantRequests: []string{"POST nexus-admins"},
+			wantMapped:   []string{"nexus-admins"},
+		},
+		{
+			name:         "existing mapping is updated",
+			existing:     []string{"nexus-admins"},
+			mapped:       `["nexus-admins"]`,
+			mappings:     []v1alpha1.KeycloakRoleMapping{mapping},
+			wantRequests: []string{"PUT nexus-admins"},
+			wantMapped:   []string{"nexus-admins"},
+		},
+		{
+			name:         "mapping removed from spec is deleted",
+			existing:     []string{"nexus-admins", "nexus-readers"},
+			mapped:       `["nexus-admins","nexus-readers"]`,
+			mappings:     []v1alpha1.KeycloakRoleMapping{mapping},
+			wantRequests: []string{"PUT nexus-admins", "DELETE nexus-readers"},
+			wantMapped:   []string{"nexus-admins"},
+		},
+		{
+			name:         "mapping of reserved role is kept when it is removed from spec",
+			existing:     []string{"nexus-admins", nexusDefaultSpec.NexusAdminRole},
+			mapped:       `["nexus-admins","nx-admin"]`,
+			mappings:     []v1alpha1.KeycloakRoleMapping{mapping},
+			wantRequests: []string{"PUT nexus-admins"},
+			wantMapped:   []string{"nexus-admins"},
+		},
+		{
+			name:     "mapping of role from default-roles ConfigMap",
+			mappings: []v1alpha1.KeycloakRoleMapping{mapping, {KeycloakRole: "edp-admin", NexusRoles: []string{"nx-admin"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "mapping without Keycloak role",
+			mappings: []v1alpha1.KeycloakRoleMapping{{NexusRoles: []string{"nx-admin"}}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &roleServer{roles: map[string]nexus.Role{}}
+			for _, id := range tt.existing {
+				s.roles[id] = nexus.Role{Id: id, Name: id}
+			}
+			server := httptest.NewServer(s)
+			defer server.Close()
+
+			instance := &v1alpha1.Nexus{}
+			instance.Name = "nexus"
+			instance.Spec.KeycloakSpec.RoleMappings = tt.mappings
+			instance.Spec.Realms = []string{"NexusAuthenticatingRealm", nexusDefaultSpec.NexusRutAuthRealm}
+			if len(tt.mapped) != 0 {
+				instance.Annotations = map[string]string{annotationKey: tt.mapped}
+			}
+			nexusClient := &nexus.NexusClient{}
+			_ = nexusClient.InitNewRestClient(instance, server.URL, "admin", "admin")
+
+			n := NexusServiceImpl{platformService: configMapPlatform{configMaps: map[string]map[string]string{
+				"nexus-default-roles": {"default-roles": `[{"id": "edp-admin", "name": "edp-admin"}]`},
+			}}}
+			err := n.syncRoleMappings(instance, nexusClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("syncRoleMappings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(s.requests, tt.wantRequests) {
+				t.Errorf("syncRoleMappings() requests = %v, want %v", s.requests, tt.wantRequests)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := getCreatedNames(*instance, roleMappingsAnnotationSuffix); !reflect.DeepEqual(got, tt.wantMapped) {
+				t.Errorf("syncRoleMappings() mapped roles = %v, want %v", got, tt.wantMapped)
+			}
+			if role := s.roles["nexus-admins"]; !reflect.DeepEqual(role.Roles, mapping.NexusRoles) {
+				t.Errorf("syncRoleMappings() roles of mapping = %v, want %v", role.Roles, mapping.NexusRoles)
+			}
+		})
+	}
+}
+
+func TestValidateRoleMappings(t *testing.T) {
+	mappings := func(roles ...string) []v1alpha1.KeycloakRoleMapping {
+		var result []v1alpha1.KeycloakRoleMapping
+		for _, role := range roles {
+			result = append(result, v1alpha1.KeycloakRoleMapping{KeycloakRole: role, NexusRoles: []string{"nx-admin"}})
+		}
+		return result
+	}
+	reserved := []string{nexusDefaultSpec.NexusAdminRole, nexusDefaultSpec.NexusAnonymousRole, "edp-admin"}
+
+	tests := []struct {
+		name    string
+		spec    v1alpha1.NexusSpec
+		wantErr bool
+	}{
+		{name: "default proxy and realms", spec: v1alpha1.NexusSpec{KeycloakSpec: v1alpha1.KeycloakSpec{RoleMappings: mappings("nexus-admins")}}},
+		{name: "realms with rutauth-realm", spec: v1alpha1.NexusSpec{Realms: []string{nexusDefaultSpec.NexusRutAuthRealm}}},
+		{
+			name:    "realms without rutauth-realm",
+			spec:    v1alpha1.NexusSpec{Realms: []string{"NexusAuthenticatingRealm"}},
+			wantErr: true,
+		},
+		{
+			name:    "proxy which doesn't pass roles",
+			spec:    v1alpha1.NexusSpec{AuthProxy: &v1alpha1.NexusAuthProxy{Type: v1alpha1.AuthProxyOAuth2Proxy}},
+			wantErr: true,
+		},
+		{
+			name:    "built-in role",
+			spec:    v1alpha1.NexusSpec{KeycloakSpec: v1alpha1.KeycloakSpec{RoleMappings: mappings(nexusDefaultSpec.NexusAnonymousRole)}},
+			wantErr: true,
+		},
+		{
+			name:    "role managed by operator",
+			spec:    v1alpha1.NexusSpec{KeycloakSpec: v1alpha1.KeycloakSpec{RoleMappings: mappings("nexus-admins", "edp-admin")}},
+			wantErr: true,
+		},
+		{
+			name:    "role mapped twice",
+			spec:    v1alpha1.NexusSpec{KeycloakSpec: v1alpha1.KeycloakSpec{RoleMappings: mappings("nexus-admins", "nexus-admins")}},
+			wantErr: true,
+		},
+		{
+			name:    "mapping without Keycloak role",
+			spec:    v1alpha1.NexusSpec{KeycloakSpec: v1alpha1.KeycloakSpec{RoleMappings: mappings("")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRoleMappings(v1alpha1.Nexus{Spec: tt.spec}, reserved); (err != nil) != tt.wantErr {
+				t.Errorf("validateRoleMappings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
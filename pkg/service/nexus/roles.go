@@ -0,0 +1,78 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+// rolesAnnotationSuffix - annotation on Nexus CR which keeps ids of roles from default-roles ConfigMap
+const rolesAnnotationSuffix = "roles"
+
+// protectedRoles are built-in roles which are never deleted by operator
+var protectedRoles = []string{nexusDefaultSpec.NexusAdminRole, nexusDefaultSpec.NexusAnonymousRole}
+
+// getDefaultRoles returns parameters of setup-role script for roles from default-roles ConfigMap
+func (n NexusServiceImpl) getDefaultRoles(instance v1alpha1.Nexus) ([]map[string]interface{}, error) {
+	data, err := n.platformService.GetConfigMapData(instance.Namespace,
+		fmt.Sprintf("%v-%v", instance.Name, nexusDefaultSpec.NexusDefaultRolesConfigMapPrefix))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get default roles from Config Map")
+	}
+	var roles []map[string]interface{}
+	if value, ok := data[nexusDefaultSpec.NexusDefaultRolesConfigMapPrefix]; ok {
+		if err = json.Unmarshal([]byte(value), &roles); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal default roles")
+		}
+	}
+	return roles, nil
+}
+
+// deleteRole deletes role, role which doesn't exist is ignored
+func (n NexusServiceImpl) deleteRole(nexusClient *nexus.NexusClient, id string) error {
+	existing, err := nexusClient.GetRole(id)
+	if err == nil {
+		if existing == nil {
+			return nil
+		}
+		err = nexusClient.DeleteRole(id)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.RolesApi, err, "delete-role", map[string]interface{}{"id": id})
+}
+
+// syncRoles creates roles from default-roles ConfigMap or updates the ones which differ from it and deletes roles
+// which have been removed from ConfigMap
+func (n NexusServiceImpl) syncRoles(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient, roles []map[string]interface{}) error {
+	var declared []string
+	for _, parameters := range roles {
+		id := getStringParameter(parameters, "id")
+		if len(id) == 0 {
+			return fmt.Errorf("id of role %v is not set", parameters["name"])
+		}
+		if err := n.setupRole(nexusClient, parameters); err != nil {
+			return errors.Wrapf(err, "failed to set up role %v", id)
+		}
+		declared = append(declared, id)
+	}
+
+	created := getCreatedNames(*instance, rolesAnnotationSuffix)
+	for _, id := range created {
+		if controllerHelper.ContainsString(declared, id) || controllerHelper.ContainsString(protectedRoles, id) {
+			continue
+		}
+		if err := n.deleteRole(nexusClient, id); err != nil {
+			return errors.Wrapf(err, "failed to delete role %v", id)
+		}
+		log.Info("Role has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "Role", id)
+	}
+
+	if reflect.DeepEqual(created, declared) {
+		return nil
+	}
+	return n.setTrackedValue(instance, rolesAnnotationSuffix, declared)
+}
@@ -0,0 +1,67 @@
+package nexus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	"github.com/pkg/errors"
+	"reflect"
+	"strings"
+)
+
+// scriptsAnnotationSuffix - annotation on Nexus CR which keeps hashes of scripts uploaded by operator
+const scriptsAnnotationSuffix = "scripts"
+
+func getScriptHash(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+// declareScripts uploads scripts from ConfigMap to Nexus, updates the ones which content has been changed since
+// the last upload and deletes the scripts which were uploaded by operator but are not in ConfigMap anymore
+func (n NexusServiceImpl) declareScripts(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient, scripts map[string]string) error {
+	existingScripts, err := nexusClient.GetScripts()
+	if err != nil {
+		return errors.Wrap(err, "failed to get list of scripts")
+	}
+	existing := map[string]bool{}
+	for _, s := range existingScripts {
+		existing[s.Name] = true
+	}
+
+	uploaded := getTrackedHashes(*instance, scriptsAnnotationSuffix)
+	declared := map[string]string{}
+	for scriptFullName, scriptContent := range scripts {
+		scriptName := strings.Split(scriptFullName, ".")[0]
+		scriptExtension := strings.Split(scriptFullName, ".")[1]
+		hash := getScriptHash(scriptContent)
+
+		if !existing[scriptName] {
+			if err := nexusClient.UploadScript(scriptName, scriptExtension, scriptContent); err != nil {
+				return err
+			}
+		} else if uploaded[scriptName] != hash {
+			if err := nexusClient.UpdateScript(scriptName, scriptExtension, scriptContent); err != nil {
+				return err
+			}
+			log.Info("Script has been updated", "Namespace", instance.Namespace, "Name", instance.Name, "Script", scriptName)
+		}
+		declared[scriptName] = hash
+	}
+
+	for scriptName := range uploaded {
+		if _, ok := declared[scriptName]; ok || !existing[scriptName] {
+			continue
+		}
+		if err := nexusClient.DeleteScript(scriptName); err != nil && !nexus.IsErrNotFound(err) {
+			return errors.Wrapf(err, "failed to delete script %v", scriptName)
+		}
+		log.Info("Script has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "Script", scriptName)
+	}
+
+	if reflect.DeepEqual(uploaded, declared) {
+		return nil
+	}
+	return n.setTrackedValue(instance, scriptsAnnotationSuffix, declared)
+}
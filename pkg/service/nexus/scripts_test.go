@@ -0,0 +1,151 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// scriptServer emulates script API of Nexus and records requests which change scripts
+type scriptServer struct {
+	mu       sync.Mutex
+	scripts  map[string]nexus.Script
+	requests []string
+}
+
+func (s *scriptServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := strings.TrimPrefix(r.URL.Path, "/script/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/script":
+		list := make([]nexus.Script, 0, len(s.scripts))
+		for _, script := range s.scripts {
+			list = append(list, script)
+		}
+		_ = json.NewEncoder(w).Encode(list)
+		return
+	case r.Method == http.MethodPost && r.URL.Path == "/script":
+		var script nexus.Script
+		if err := json.NewDecoder(r.Body).Decode(&script); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.scripts[script.Name] = script
+		name = script.Name
+	case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+		if _, ok := s.scripts[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.scripts, name)
+		if r.Method == http.MethodPut {
+			var script nexus.Script
+			_ = json.NewDecoder(r.Body).Decode(&script)
+			s.scripts[name] = script
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.requests = append(s.requests, fmt.Sprintf("%v %v", r.Method, name))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestDeclareScripts(t *testing.T) {
+	hashes := func(scripts map[string]string) string {
+		value, _ := json.Marshal(scripts)
+		return string(value)
+	}
+	annotationKey := helper.GenerateAnnotationKey(scriptsAnnotationSuffix)
+
+	tests := []struct {
+		name         string
+		existing     []string
+		uploaded     map[string]string
+		scripts      map[string]string
+		wantRequests []string
+		wantUploaded map[string]string
+	}{
+		{
+			name:         "absent scripts are uploaded",
+			scripts:      map[string]string{"setup-role.groovy": "role", "setup-user.groovy": "user"},
+			wantRequests: []string{"POST setup-role", "POST setup-user"},
+			wantUploaded: map[string]string{"setup-role": getScriptHash("role"), "setup-user": getScriptHash("user")},
+		},
+		{
+			name:         "unchanged script is kept",
+			existing:     []string{"setup-role"},
+			uploaded:     map[string]string{"setup-role": getScriptHash("role")},
+			scripts:      map[string]string{"setup-role.groovy": "role"},
+			wantUploaded: map[string]string{"setup-role": getScriptHash("role")},
+		},
+		{
+			name:         "changed script is updated",
+			existing:     []string{"setup-role"},
+			uploaded:     map[string]string{"setup-role": getScriptHash("role")},
+			scripts:      map[string]string{"setup-role.groovy": "changed role"},
+			wantRequests: []string{"PUT setup-role"},
+			wantUploaded: map[string]string{"setup-role": getScriptHash("changed role")},
+		},
+		{
+			name:         "script uploaded by previous operator version is updated",
+			existing:     []string{"setup-role"},
+			scripts:      map[string]string{"setup-role.groovy": "role"},
+			wantRequests: []string{"PUT setup-role"},
+			wantUploaded: map[string]string{"setup-role": getScriptHash("role")},
+		},
+		{
+			name:         "script deleted in Nexus is uploaded again",
+			uploaded:     map[string]string{"setup-role": getScriptHash("role")},
+			scripts:      map[string]string{"setup-role.groovy": "role"},
+			wantRequests: []string{"POST setup-role"},
+			wantUploaded: map[string]string{"setup-role": getScriptHash("role")},
+		},
+		{
+			name:         "obsolete script is deleted, script not uploaded by operator is kept",
+			existing:     []string{"setup-role", "setup-user", "custom"},
+			uploaded:     map[string]string{"setup-role": getScriptHash("role"), "setup-user": getScriptHash("user")},
+			scripts:      map[string]string{"setup-role.groovy": "role"},
+			wantRequests: []string{"DELETE setup-user"},
+			wantUploaded: map[string]string{"setup-role": getScriptHash("role")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &scriptServer{scripts: map[string]nexus.Script{}}
+			for _, name := range tt.existing {
+				s.scripts[name] = nexus.Script{Name: name, Type: "groovy"}
+			}
+			server := httptest.NewServer(s)
+			defer server.Close()
+
+			instance := &v1alpha1.Nexus{}
+			if tt.uploaded != nil {
+				instance.Annotations = map[string]string{annotationKey: hashes(tt.uploaded)}
+			}
+			nexusClient := &nexus.NexusClient{}
+			_ = nexusClient.InitNewRestClient(instance, server.URL, "admin", "admin")
+
+			if err := (NexusServiceImpl{}).declareScripts(instance, nexusClient, tt.scripts); err != nil {
+				t.Fatalf("declareScripts() error = %v", err)
+			}
+			sort.Strings(s.requests)
+			if !reflect.DeepEqual(s.requests, tt.wantRequests) {
+				t.Errorf("declareScripts() requests = %v, want %v", s.requests, tt.wantRequests)
+			}
+			if got := instance.Annotations[annotationKey]; got != hashes(tt.wantUploaded) {
+				t.Errorf("declareScripts() uploaded scripts = %v, want %v", got, hashes(tt.wantUploaded))
+			}
+		})
+	}
+}
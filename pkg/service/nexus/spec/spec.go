@@ -25,15 +25,21 @@ const (
 	//EdpAnnotationsPrefix general prefix for all annotation made by EDP team
 	EdpAnnotationsPrefix = "edp.epam.com"
 
-	//IdentityServiceCredentialsSecretPostfix
+	//IdentityServiceCredentialsSecretPostfix - postfix of Secret with client secret of Nexus Keycloak client
 	IdentityServiceCredentialsSecretPostfix = "is-credentials"
 
+	//KeycloakClientSecretKey - key of client secret in Secret of Keycloak client
+	KeycloakClientSecretKey = "clientSecret"
+
 	//NexusPort - default Nexus port
 	NexusPort = 8081
 
 	//NexusMemoryRequest - default request value for memory request for deployment config
 	NexusMemoryRequest = "500Mi"
 
+	//NexusJavaPrefsOption - JVM option which sets location of Java preferences, it is required by Nexus image
+	NexusJavaPrefsOption = "-Djava.util.prefs.userRoot"
+
 	//NexusDefaultAdminUser - default admin username in Nexus
 	NexusDefaultAdminUser string = "admin"
 
@@ -48,5 +54,132 @@ const (
 
 	NexusKeycloakProxyPort int32 = 3000
 
+	//NexusDefaultBlobStore - blob store used for repositories which don't specify one
+	NexusDefaultBlobStore = "default"
+
+	//NexusDefaultS3BlobStoreRegion - region used for S3 blob stores which don't specify one
+	NexusDefaultS3BlobStoreRegion = "us-east-1"
+
+	//NexusDefaultS3BlobStoreExpiration - days after which deleted blobs are removed from bucket of S3 blob store
+	NexusDefaultS3BlobStoreExpiration = 3
+
+	//SoftQuotaTypeSpaceUsed - soft quota which is violated when space used by blob store exceeds limit
+	SoftQuotaTypeSpaceUsed = "spaceUsedQuota"
+
+	//SoftQuotaTypeSpaceRemaining - soft quota which is violated when space available to blob store falls below limit
+	SoftQuotaTypeSpaceRemaining = "spaceRemainingQuota"
+
+	//CleanupPolicyAllFormats - format of cleanup policy which can be attached to repositories of any format
+	CleanupPolicyAllFormats = "ALL_FORMATS"
+
+	//NexusCleanupTaskName - name of task which applies cleanup policies
+	NexusCleanupTaskName = "Cleanup service"
+
+	//NexusCleanupTaskType - type of task which applies cleanup policies
+	NexusCleanupTaskType = "repository.cleanup"
+
+	//NexusDefaultCleanupTaskCron - schedule of task which applies cleanup policies if it is not set in spec
+	NexusDefaultCleanupTaskCron = "0 0 1 * * ?"
+
+	//S3CredentialsSecretAccessKeyIdKey - key of access key id in Secret with credentials of S3 blob store
+	S3CredentialsSecretAccessKeyIdKey = "accessKeyId"
+
+	//S3CredentialsSecretSecretAccessKeyKey - key of secret access key in Secret with credentials of S3 blob store
+	S3CredentialsSecretSecretAccessKeyKey = "secretAccessKey"
+
+	//S3CredentialsSecretSessionTokenKey - optional key of session token in Secret with credentials of S3 blob store
+	S3CredentialsSecretSessionTokenKey = "sessionToken"
+
+	//NexusDefaultWritePolicy - write policy used for hosted repositories which don't specify one
+	NexusDefaultWritePolicy = "allow"
+
+	//NexusDefaultVersionPolicy - version policy used for maven repositories which don't specify one
+	NexusDefaultVersionPolicy = "release"
+
+	//NexusDefaultLayoutPolicy - layout policy used for maven repositories which don't specify one
+	NexusDefaultLayoutPolicy = "strict"
+
+	//NexusDefaultRawContentDisposition - content disposition used for raw repositories which don't specify one
+	NexusDefaultRawContentDisposition = "ATTACHMENT"
+
+	//NexusDefaultYumDeployPolicy - deploy policy used for hosted yum repositories which don't specify one
+	NexusDefaultYumDeployPolicy = "STRICT"
+
+	//SigningSecretKeypairKey - key of GPG keypair in Secret which signs apt and yum repositories
+	SigningSecretKeypairKey = "keypair"
+
+	//SigningSecretPassphraseKey - key of passphrase of GPG keypair in Secret which signs apt and yum repositories
+	SigningSecretPassphraseKey = "passphrase"
+
+	//NexusDefaultUserEmailDomain - domain of generated email for users which don't specify one, email is mandatory in REST API
+	NexusDefaultUserEmailDomain = "nexus.local"
+
+	//NexusBackupImage - image of Job which archives Nexus data before upgrade
+	NexusBackupImage = "busybox:1.32"
+
+	//NexusBackupArchivesToKeep - number of the latest archives kept on backup volume, older ones are removed
+	NexusBackupArchivesToKeep = 2
+
 	NexusKeycloakProxyImage string = "quay.io/keycloak/keycloak-gatekeeper:10.0.0"
+
+	//NexusOAuth2ProxyImage - default image of oauth2-proxy
+	NexusOAuth2ProxyImage string = "quay.io/oauth2-proxy/oauth2-proxy:v7.1.3"
+
+	//NexusOpenshiftOAuthProxyImage - default image of OpenShift oauth-proxy
+	NexusOpenshiftOAuthProxyImage string = "quay.io/openshift/origin-oauth-proxy:4.7"
+
+	//NexusOAuth2ProxyPort - port of oauth2-proxy and OpenShift oauth-proxy
+	NexusOAuth2ProxyPort int32 = 4180
+
+	//NexusAuthProxyContainerName - name of authentication proxy sidecar and its Service port,
+	//the name is kept for compatibility with deployments created with Keycloak proxy only
+	NexusAuthProxyContainerName = "keycloak-proxy"
+
+	//NexusAuthProxyCookieSecretPostfix - postfix of Secret with cookie secret of oauth2-proxy and OpenShift oauth-proxy
+	NexusAuthProxyCookieSecretPostfix = "proxy-cookie"
+
+	//NexusAuthProxyCookieSecretKey - key of cookie secret in Secret
+	NexusAuthProxyCookieSecretKey = "cookieSecret"
+
+	//NexusAuthenticatingRealm - realm of local users, it can not be deactivated since operator authenticates in it
+	NexusAuthenticatingRealm = "NexusAuthenticatingRealm"
+
+	//NexusDefaultAnonymousUser - user whose permissions are granted to unauthenticated users if it is not set in spec
+	NexusDefaultAnonymousUser = "anonymous"
+
+	//NexusAuthorizingRealm - realm of local users which is used for anonymous user if it is not set in spec
+	NexusAuthorizingRealm = "NexusAuthorizingRealm"
+
+	//NexusAdminRole - built-in role with all privileges, it is never deleted by operator
+	NexusAdminRole = "nx-admin"
+
+	//NexusAnonymousRole - built-in role of anonymous user, it is never deleted by operator
+	NexusAnonymousRole = "nx-anonymous"
+
+	//NexusBaseUrlCapability - type of capability which keeps base URL of Nexus
+	NexusBaseUrlCapability = "baseurl"
+
+	//NexusDockerTokenRealm - realm which authenticates docker clients
+	NexusDockerTokenRealm = "DockerToken"
+
+	//DockerHubRegistryHost - host of Docker Hub registry, proxy repositories of Docker Hub use its index
+	DockerHubRegistryHost = "registry-1.docker.io"
+
+	//NexusLdapRealm - realm which authenticates users in LDAP server
+	NexusLdapRealm = "LdapRealm"
+
+	//NexusRutAuthRealm - realm which authenticates users by header set by authentication proxy
+	NexusRutAuthRealm = "rutauth-realm"
+
+	//NexusDefaultLdapServerName - name of LDAP server in Nexus if it is not set in spec
+	NexusDefaultLdapServerName = "ldap"
+
+	//NexusLdapConnectionTimeoutSeconds - default timeout of connection to LDAP server
+	NexusLdapConnectionTimeoutSeconds int32 = 30
+
+	//NexusLdapConnectionRetryDelaySeconds - default delay before retry of failed connection to LDAP server
+	NexusLdapConnectionRetryDelaySeconds int32 = 300
+
+	//NexusLdapMaxIncidentsCount - default number of connection failures before LDAP server is blacklisted
+	NexusLdapMaxIncidentsCount int32 = 3
 )
@@ -0,0 +1,195 @@
+package nexus
+
+import (
+	"encoding/json"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"reflect"
+	"sort"
+)
+
+// tasksAnnotationSuffix - annotation on Nexus CR which keeps hashes of parameters of tasks created by operator
+const tasksAnnotationSuffix = "tasks"
+
+// getDeclaredTasks returns parameters of create-task script for tasks from ConfigMap and spec, Cleanup service task
+// is added if cleanup policies are set. Task from spec overrides the one from ConfigMap with the same name
+func getDeclaredTasks(instance v1alpha1.Nexus, configMapTasks []map[string]interface{}) []map[string]interface{} {
+	var tasks []map[string]interface{}
+	index := map[string]int{}
+	add := func(task map[string]interface{}) {
+		name := getStringParameter(task, "name")
+		if i, ok := index[name]; ok {
+			tasks[i] = task
+			return
+		}
+		index[name] = len(tasks)
+		tasks = append(tasks, task)
+	}
+
+	for _, task := range configMapTasks {
+		add(task)
+	}
+	if len(instance.Spec.CleanupPolicies) != 0 {
+		cron := instance.Spec.CleanupTaskCron
+		if len(cron) == 0 {
+			cron = nexusDefaultSpec.NexusDefaultCleanupTaskCron
+		}
+		add(map[string]interface{}{
+			"name":   nexusDefaultSpec.NexusCleanupTaskName,
+			"typeId": nexusDefaultSpec.NexusCleanupTaskType,
+			"cron":   cron,
+		})
+	}
+	for _, task := range instance.Spec.Tasks {
+		parameters := map[string]interface{}{
+			"name":   task.Name,
+			"typeId": task.TypeId,
+			"cron":   task.Cron,
+		}
+		if len(task.Properties) != 0 {
+			parameters["taskProperties"] = task.Properties
+		}
+		add(parameters)
+	}
+	return tasks
+}
+
+// syncTasks creates tasks which are absent in Nexus, recreates the ones which type differs from the declared one
+// or which parameters have been changed since the last sync and deletes the tasks which were created by operator
+// but are not declared anymore. Schedule and properties are not returned by REST API, so only their hash is compared.
+// Tasks are managed by scripts only since REST API doesn't allow to create them
+func (n NexusServiceImpl) syncTasks(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient, tasks []map[string]interface{}) error {
+	created := getTrackedHashes(*instance, tasksAnnotationSuffix)
+	// existing maps names of tasks in Nexus to their types
+	existing := map[string]string{}
+	existingTasks, err := nexusClient.GetTasks()
+	if err != nil {
+		if !nexus.IsErrNotSupported(err) {
+			return errors.Wrap(err, "failed to get list of tasks")
+		}
+		// Tasks API is missing in old Nexus versions, tasks created by operator are considered to be present there
+		for name := range created {
+			existing[name] = ""
+		}
+	}
+	for _, t := range existingTasks {
+		existing[t.Name] = t.Type
+	}
+
+	declared := map[string]string{}
+	for _, task := range tasks {
+		name := getStringParameter(task, "name")
+		content, err := json.Marshal(task)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal parameters of task %v", name)
+		}
+		hash := getScriptHash(string(content))
+		existingType, ok := existing[name]
+		typeChanged := len(existingType) != 0 && existingType != getStringParameter(task, "typeId")
+		if !ok || typeChanged || created[name] != hash {
+			if _, err := nexusClient.RunScript("create-task", task); err != nil {
+				return errors.Wrapf(err, "failed to create task %v", name)
+			}
+			log.Info("Task has been scheduled", "Namespace", instance.Namespace, "Name", instance.Name, "Task", name)
+		}
+		declared[name] = hash
+	}
+
+	for name := range created {
+		if _, ok := declared[name]; ok {
+			continue
+		}
+		if _, ok := existing[name]; !ok {
+			continue
+		}
+		if _, err := nexusClient.RunScript("delete-task", map[string]interface{}{"name": name}); err != nil {
+			return errors.Wrapf(err, "failed to delete task %v", name)
+		}
+		log.Info("Task has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "Task", name)
+	}
+
+	if reflect.DeepEqual(created, declared) {
+		return nil
+	}
+	return n.setTrackedValue(instance, tasksAnnotationSuffix, declared)
+}
+
+// getTaskStatus converts task returned by REST API to its status
+func getTaskStatus(task nexus.Task) v1alpha1.NexusTaskStatus {
+	return v1alpha1.NexusTaskStatus{
+		Name:          task.Name,
+		Type:          task.Type,
+		CurrentState:  task.CurrentState,
+		LastRun:       task.LastRun,
+		LastRunResult: task.LastRunResult,
+		NextRun:       task.NextRun,
+	}
+}
+
+// GetTasksStatus returns state of tasks created by operator sorted by name
+func (n NexusServiceImpl) GetTasksStatus(instance v1alpha1.Nexus) ([]v1alpha1.NexusTaskStatus, error) {
+	created := getTrackedHashes(instance, tasksAnnotationSuffix)
+	if len(created) == 0 {
+		return nil, nil
+	}
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := nexusClient.GetTasks()
+	if err != nil {
+		if nexus.IsErrNotSupported(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get list of tasks")
+	}
+
+	var status []v1alpha1.NexusTaskStatus
+	for _, t := range tasks {
+		if _, ok := created[t.Name]; !ok {
+			continue
+		}
+		status = append(status, getTaskStatus(t))
+	}
+	sort.Slice(status, func(i, j int) bool { return status[i].Name < status[j].Name })
+	return status, nil
+}
+
+// GetTaskStatus returns state of task by name or nil if it doesn't exist
+func (n NexusServiceImpl) GetTaskStatus(instance v1alpha1.Nexus, name string) (*v1alpha1.NexusTaskStatus, error) {
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return nil, err
+	}
+	task, err := nexusClient.GetTaskByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get task %v", name)
+	}
+	if task == nil {
+		return nil, nil
+	}
+	status := getTaskStatus(*task)
+	return &status, nil
+}
+
+// RunTask starts task by name and returns its state before the run or nil if task doesn't exist
+func (n NexusServiceImpl) RunTask(instance v1alpha1.Nexus, name string) (*v1alpha1.NexusTaskStatus, error) {
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return nil, err
+	}
+	task, err := nexusClient.GetTaskByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get task %v", name)
+	}
+	if task == nil {
+		return nil, nil
+	}
+	if err := nexusClient.RunTask(task.Id); err != nil {
+		return nil, errors.Wrapf(err, "failed to run task %v", name)
+	}
+	status := getTaskStatus(*task)
+	return &status, nil
+}
@@ -0,0 +1,170 @@
+package nexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetDeclaredTasks(t *testing.T) {
+	compact := map[string]interface{}{"name": "Compact", "typeId": "blobstore.compact", "cron": "0 0 2 * * ?"}
+	rebuild := map[string]interface{}{"name": "Rebuild", "typeId": "repository.rebuild-index", "cron": "0 0 3 * * ?"}
+	cleanup := func(cron string) map[string]interface{} {
+		return map[string]interface{}{"name": nexusDefaultSpec.NexusCleanupTaskName, "typeId": nexusDefaultSpec.NexusCleanupTaskType, "cron": cron}
+	}
+	policies := []v1alpha1.NexusCleanupPolicy{{Name: "unused", LastDownloadedDays: 90}}
+
+	tests := []struct {
+		name           string
+		spec           v1alpha1.NexusSpec
+		configMapTasks []map[string]interface{}
+		want           []map[string]interface{}
+	}{
+		{name: "no tasks"},
+		{
+			name:           "tasks from ConfigMap",
+			configMapTasks: []map[string]interface{}{compact, rebuild},
+			want:           []map[string]interface{}{compact, rebuild},
+		},
+		{
+			name: "task from spec with properties",
+			spec: v1alpha1.NexusSpec{Tasks: []v1alpha1.NexusTask{
+				{Name: "Compact", TypeId: "blobstore.compact", Cron: "0 0 4 * * ?", Properties: map[string]string{"blobstoreName": "default"}},
+			}},
+			want: []map[string]interface{}{
+				{"name": "Compact", "typeId": "blobstore.compact", "cron": "0 0 4 * * ?", "taskProperties": map[string]string{"blobstoreName": "default"}},
+			},
+		},
+		{
+			name: "task from spec overrides the one from ConfigMap",
+			spec: v1alpha1.NexusSpec{Tasks: []v1alpha1.NexusTask{
+				{Name: "Compact", TypeId: "blobstore.compact", Cron: "0 0 4 * * ?"},
+			}},
+			configMapTasks: []map[string]interface{}{compact, rebuild},
+			want: []map[string]interface{}{
+				{"name": "Compact", "typeId": "blobstore.compact", "cron": "0 0 4 * * ?"},
+				rebuild,
+			},
+		},
+		{
+			name:           "cleanup task with default schedule",
+			spec:           v1alpha1.NexusSpec{CleanupPolicies: policies},
+			configMapTasks: []map[string]interface{}{compact},
+			want:           []map[string]interface{}{compact, cleanup(nexusDefaultSpec.NexusDefaultCleanupTaskCron)},
+		},
+		{
+			name: "cleanup task with schedule from spec",
+			spec: v1alpha1.NexusSpec{CleanupPolicies: policies, CleanupTaskCron: "0 30 0 * * ?"},
+			want: []map[string]interface{}{cleanup("0 30 0 * * ?")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getDeclaredTasks(v1alpha1.Nexus{Spec: tt.spec}, tt.configMapTasks)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDeclaredTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// taskServer emulates tasks API of Nexus and records runs of task scripts
+type taskServer struct {
+	tasks    []nexus.Task
+	requests []string
+}
+
+func (s *taskServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/tasks":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": s.tasks})
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/script/"):
+		var parameters map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&parameters)
+		script := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/script/"), "/run")
+		s.requests = append(s.requests, fmt.Sprintf("%v %v", script, parameters["name"]))
+		_, _ = w.Write([]byte(`{"result":"ok"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSyncTasks(t *testing.T) {
+	compact := map[string]interface{}{"name": "Compact", "typeId": "blobstore.compact", "cron": "0 0 2 * * ?"}
+	hash := func(task map[string]interface{}) string {
+		content, _ := json.Marshal(task)
+		return getScriptHash(string(content))
+	}
+	created := func(hashes map[string]string) map[string]string {
+		value, _ := json.Marshal(hashes)
+		return map[string]string{helper.GenerateAnnotationKey(tasksAnnotationSuffix): string(value)}
+	}
+
+	tests := []struct {
+		name         string
+		existing     []nexus.Task
+		annotations  map[string]string
+		tasks        []map[string]interface{}
+		wantRequests []string
+		wantCreated  map[string]string
+	}{
+		{
+			name:         "absent task is created",
+			tasks:        []map[string]interface{}{compact},
+			wantRequests: []string{"create-task Compact"},
+			wantCreated:  map[string]string{"Compact": hash(compact)},
+		},
+		{
+			name:        "unchanged task is kept",
+			existing:    []nexus.Task{{Name: "Compact", Type: "blobstore.compact"}},
+			annotations: created(map[string]string{"Compact": hash(compact)}),
+			tasks:       []map[string]interface{}{compact},
+			wantCreated: map[string]string{"Compact": hash(compact)},
+		},
+		{
+			name:         "task of other type is recreated",
+			existing:     []nexus.Task{{Name: "Compact", Type: "repository.rebuild-index"}},
+			annotations:  created(map[string]string{"Compact": hash(compact)}),
+			tasks:        []map[string]interface{}{compact},
+			wantRequests: []string{"create-task Compact"},
+			wantCreated:  map[string]string{"Compact": hash(compact)},
+		},
+		{
+			name:         "task created by operator is deleted, task deleted in Nexus is skipped",
+			existing:     []nexus.Task{{Name: "Rebuild", Type: "repository.rebuild-index"}},
+			annotations:  created(map[string]string{"Rebuild": "1", "Compact": "2"}),
+			wantRequests: []string{"delete-task Rebuild"},
+			wantCreated:  map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &taskServer{tasks: tt.existing}
+			server := httptest.NewServer(s)
+			defer server.Close()
+
+			instance := &v1alpha1.Nexus{}
+			instance.Annotations = tt.annotations
+			nexusClient := &nexus.NexusClient{}
+			_ = nexusClient.InitNewRestClient(instance, server.URL, "admin", "admin")
+
+			if err := (NexusServiceImpl{}).syncTasks(instance, nexusClient, tt.tasks); err != nil {
+				t.Fatalf("syncTasks() error = %v", err)
+			}
+			if !reflect.DeepEqual(s.requests, tt.wantRequests) {
+				t.Errorf("syncTasks() requests = %v, want %v", s.requests, tt.wantRequests)
+			}
+			if got := getTrackedHashes(*instance, tasksAnnotationSuffix); !reflect.DeepEqual(got, tt.wantCreated) {
+				t.Errorf("syncTasks() created tasks = %v, want %v", got, tt.wantCreated)
+			}
+		})
+	}
+}
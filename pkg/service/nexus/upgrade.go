@@ -0,0 +1,131 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"strings"
+)
+
+// orientDbLastVersion - the last Nexus version which runs on OrientDB, newer versions require database migration
+var orientDbLastVersion = nexus.Version{3, 70, 99}
+
+func validateUpgradePath(from string, to string) error {
+	fromVersion, err := nexus.ParseVersion(from)
+	if err != nil {
+		return err
+	}
+	toVersion, err := nexus.ParseVersion(to)
+	if err != nil {
+		return err
+	}
+
+	if toVersion.Less(fromVersion) {
+		return fmt.Errorf("downgrade from %v to %v is not supported", from, to)
+	}
+	if fromVersion[0] != toVersion[0] {
+		return fmt.Errorf("upgrade from %v to %v changes major version", from, to)
+	}
+	if !orientDbLastVersion.Less(fromVersion) && orientDbLastVersion.Less(toVersion) {
+		return fmt.Errorf("upgrade from %v to %v requires migration from OrientDB which should be done manually", from, to)
+	}
+	return nil
+}
+
+func getBackupJobName(instance v1alpha1.Nexus) string {
+	return fmt.Sprintf("%v-backup-%v", instance.Name, strings.Replace(instance.Status.CurrentVersion, ".", "-", -1))
+}
+
+// ValidateUpgrade checks if Nexus can be upgraded from the deployed version to the version from spec
+func (n NexusServiceImpl) ValidateUpgrade(instance v1alpha1.Nexus) error {
+	if err := validateUpgradePath(instance.Status.CurrentVersion, instance.Spec.Version); err != nil {
+		return err
+	}
+
+	jobName := getBackupJobName(instance)
+	status, err := n.platformService.GetJobStatus(instance.Namespace, jobName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get backup Job %v", jobName)
+	}
+	if status.Failed > 0 {
+		return fmt.Errorf("backup Job %v has failed, it should be deleted to retry upgrade", jobName)
+	}
+	return nil
+}
+
+// SetReadOnly freezes or releases Nexus database
+func (n NexusServiceImpl) SetReadOnly(instance v1alpha1.Nexus, readOnly bool) error {
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return err
+	}
+
+	state, err := nexusClient.GetReadOnlyState()
+	if err != nil {
+		return errors.Wrap(err, "failed to get read-only state")
+	}
+	if state.Frozen == readOnly {
+		return nil
+	}
+
+	if readOnly {
+		err = nexusClient.FreezeReadOnly()
+	} else {
+		err = nexusClient.ReleaseReadOnly()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to set read-only mode to %v", readOnly)
+	}
+	log.Info("Read-only mode has been changed", "Namespace", instance.Namespace, "Name", instance.Name, "ReadOnly", readOnly)
+	return nil
+}
+
+// Backup starts Job which archives Nexus data of the deployed version and reports whether it has been completed.
+// Only data volume is archived, S3 blob stores are not backed up
+func (n NexusServiceImpl) Backup(instance v1alpha1.Nexus) (bool, error) {
+	jobName := getBackupJobName(instance)
+	archiveName := fmt.Sprintf("%v-%v.tar.gz", instance.Name, instance.Status.CurrentVersion)
+	if err := n.platformService.CreateBackupJob(instance, jobName, archiveName); err != nil {
+		return false, errors.Wrapf(err, "failed to create backup Job %v", jobName)
+	}
+
+	status, err := n.platformService.GetJobStatus(instance.Namespace, jobName)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get backup Job %v", jobName)
+	}
+	if status.Failed > 0 {
+		return false, fmt.Errorf("backup Job %v has failed", jobName)
+	}
+	return status.Succeeded > 0, nil
+}
+
+// IsUpgraded checks that Nexus of the version from spec is up and serves REST API
+func (n NexusServiceImpl) IsUpgraded(instance v1alpha1.Nexus) (bool, error) {
+	ready, err := n.platformService.IsDeploymentReady(instance)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check if deployment is ready")
+	}
+	if !*ready {
+		return false, nil
+	}
+
+	nexusClient, err := n.getNexusClient(instance)
+	if err != nil {
+		return false, err
+	}
+	if apiIsReady, _, err := nexusClient.IsNexusRestApiReady(); err != nil || !apiIsReady {
+		return false, nil
+	}
+
+	// Deployment may still report the old pod as ready, so the version served by Nexus is checked as well
+	version, err := nexusClient.GetServerVersion()
+	if err != nil {
+		return false, nil
+	}
+	return len(version) == 0 || strings.HasPrefix(version, instance.Spec.Version), nil
+}
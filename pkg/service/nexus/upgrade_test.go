@@ -0,0 +1,31 @@
+package nexus
+
+import "testing"
+
+func TestValidateUpgradePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "minor upgrade", from: "3.28.1", to: "3.29.0"},
+		{name: "patch upgrade with build number", from: "3.29.0-02", to: "3.29.2-02"},
+		{name: "same version", from: "3.29.0", to: "3.29.0"},
+		{name: "last OrientDB version", from: "3.29.0", to: "3.70.99"},
+		{name: "upgrade after migration", from: "3.71.0", to: "3.72.0"},
+		{name: "downgrade", from: "3.29.0", to: "3.28.1", wantErr: true},
+		{name: "major upgrade", from: "3.29.0", to: "4.0.0", wantErr: true},
+		{name: "OrientDB migration", from: "3.70.1", to: "3.71.0", wantErr: true},
+		{name: "malformed current version", from: "latest", to: "3.29.0", wantErr: true},
+		{name: "malformed target version", from: "3.29.0", to: "3.29", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpgradePath(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUpgradePath(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package nexus
+
+import (
+	"fmt"
+	"github.com/dchest/uniuri"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/client/nexus"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	"github.com/pkg/errors"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// usersAnnotationSuffix - annotation on Nexus CR which keeps names of users from spec created by operator
+const usersAnnotationSuffix = "users"
+
+// protectedUsers are never disabled or deleted by operator since it relies on them
+var protectedUsers = []string{nexusDefaultSpec.NexusDefaultAdminUser, nexusDefaultSpec.NexusDefaultAnonymousUser}
+
+// validateUsers checks that users from spec have unique names
+func validateUsers(users []v1alpha1.NexusUsers) error {
+	var names []string
+	for _, u := range users {
+		if len(u.Username) == 0 {
+			return errors.New("username is not set")
+		}
+		if controllerHelper.ContainsString(names, u.Username) {
+			return fmt.Errorf("user %v is declared more than once", u.Username)
+		}
+		names = append(names, u.Username)
+	}
+	return nil
+}
+
+// invalidSecretNameCharacters matches characters of username which can not be used in Secret name
+var invalidSecretNameCharacters = regexp.MustCompile(`[^a-z0-9.-]`)
+
+// getUserSecretName returns name of Secret which keeps initial password of user from spec
+func getUserSecretName(instance v1alpha1.Nexus, username string) string {
+	return fmt.Sprintf("%v-user-%v", instance.Name, invalidSecretNameCharacters.ReplaceAllString(strings.ToLower(username), "-"))
+}
+
+// getUserPassword returns initial password of user from spec. Random password is generated and saved to Secret
+// when Secret doesn't exist yet, Secret is kept when user is removed from spec
+func (n NexusServiceImpl) getUserPassword(instance v1alpha1.Nexus, username string) (string, error) {
+	secretName := getUserSecretName(instance, username)
+	err := n.platformService.CreateSecret(instance, secretName, map[string][]byte{
+		"username": []byte(username),
+		"password": []byte(uniuri.New()),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create Secret %v", secretName)
+	}
+	data, err := n.platformService.GetSecretData(instance.Namespace, secretName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get Secret %v", secretName)
+	}
+	return string(data["password"]), nil
+}
+
+// disableUser disables user, user which doesn't exist or is already disabled is ignored
+func (n NexusServiceImpl) disableUser(nexusClient *nexus.NexusClient, username string) error {
+	existing, err := nexusClient.GetUser(username)
+	if err == nil {
+		if existing == nil || existing.Status == nexus.UserStatusDisabled {
+			return nil
+		}
+		existing.Status = nexus.UserStatusDisabled
+		err = nexusClient.UpdateUser(*existing)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.UsersApi, err, "disable-user", map[string]interface{}{"username": username})
+}
+
+// deleteUser deletes user, user which doesn't exist is ignored
+func (n NexusServiceImpl) deleteUser(nexusClient *nexus.NexusClient, username string) error {
+	existing, err := nexusClient.GetUser(username)
+	if err == nil {
+		if existing == nil {
+			return nil
+		}
+		err = nexusClient.DeleteUser(username)
+	}
+	return runScriptIfNotSupported(nexusClient, nexus.UsersApi, err, "delete-user", map[string]interface{}{"username": username})
+}
+
+// syncUsers creates users from spec or updates their attributes and roles. Users which have been created by operator
+// but removed from spec are disabled or deleted according to removal policy. Initial password from user Secret is set
+// only for new users
+func (n NexusServiceImpl) syncUsers(instance *v1alpha1.Nexus, nexusClient *nexus.NexusClient) error {
+	if err := validateUsers(instance.Spec.Users); err != nil {
+		return err
+	}
+
+	var declared []string
+	for _, user := range instance.Spec.Users {
+		password, err := n.getUserPassword(*instance, user.Username)
+		if err != nil {
+			return err
+		}
+		parameters := map[string]interface{}{
+			"username":   user.Username,
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+			"email":      user.Email,
+			"password":   password,
+			"roles":      user.Roles,
+		}
+		if err := n.setupUser(nexusClient, parameters); err != nil {
+			return errors.Wrapf(err, "failed to set up user %v", user.Username)
+		}
+		declared = append(declared, user.Username)
+	}
+
+	created := getCreatedNames(*instance, usersAnnotationSuffix)
+	for _, name := range created {
+		if controllerHelper.ContainsString(declared, name) || controllerHelper.ContainsString(protectedUsers, name) {
+			continue
+		}
+		if instance.Spec.UserRemovalPolicy == v1alpha1.UserRemovalPolicyDelete {
+			if err := n.deleteUser(nexusClient, name); err != nil {
+				return errors.Wrapf(err, "failed to delete user %v", name)
+			}
+			log.Info("User has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "User", name)
+			continue
+		}
+		if err := n.disableUser(nexusClient, name); err != nil {
+			return errors.Wrapf(err, "failed to disable user %v", name)
+		}
+		log.Info("User has been disabled", "Namespace", instance.Namespace, "Name", instance.Name, "User", name)
+	}
+
+	if reflect.DeepEqual(created, declared) {
+		return nil
+	}
+	return n.setTrackedValue(instance, usersAnnotationSuffix, declared)
+}
@@ -0,0 +1,53 @@
+package nexus
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestValidateUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		users   []v1alpha1.NexusUsers
+		wantErr bool
+	}{
+		{name: "no users"},
+		{name: "unique users", users: []v1alpha1.NexusUsers{
+			{Username: "jenkins", Roles: []string{"nx-admin"}},
+			{Username: "ci-reader"},
+		}},
+		{name: "username is not set", users: []v1alpha1.NexusUsers{
+			{FirstName: "Jenkins"},
+		}, wantErr: true},
+		{name: "duplicated username", users: []v1alpha1.NexusUsers{
+			{Username: "jenkins"},
+			{Username: "jenkins", Roles: []string{"nx-admin"}},
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUsers(tt.users)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUsers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetUserSecretName(t *testing.T) {
+	instance := v1alpha1.Nexus{ObjectMeta: metav1.ObjectMeta{Name: "nexus"}}
+	tests := []struct {
+		username string
+		want     string
+	}{
+		{username: "jenkins", want: "nexus-user-jenkins"},
+		{username: "CI.Reader", want: "nexus-user-ci.reader"},
+		{username: "john_doe@example.com", want: "nexus-user-john-doe-example.com"},
+	}
+	for _, tt := range tests {
+		if got := getUserSecretName(instance, tt.username); got != tt.want {
+			t.Errorf("getUserSecretName(%q) = %v, want %v", tt.username, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package helper
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
+	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"strings"
+)
+
+// GenerateNexusEnv returns environment variables of Nexus container: context path, JVM options and
+// additional variables from spec
+func GenerateNexusEnv(instance v1alpha1.Nexus) []coreV1Api.EnvVar {
+	nexusContextEnv := "/"
+	if len(instance.Spec.BasePath) != 0 {
+		nexusContextEnv = instance.Spec.BasePath
+	}
+
+	env := []coreV1Api.EnvVar{
+		{
+			Name:  "NEXUS_CONTEXT",
+			Value: nexusContextEnv,
+		},
+	}
+	if instance.Spec.JvmOptions != nil {
+		env = append(env, coreV1Api.EnvVar{
+			Name:  "INSTALL4J_ADD_VM_PARAMS",
+			Value: generateJvmParams(*instance.Spec.JvmOptions),
+		})
+	}
+	return append(env, instance.Spec.Env...)
+}
+
+func generateJvmParams(options v1alpha1.NexusJvmOptions) string {
+	var params []string
+	if len(options.HeapSize) != 0 {
+		params = append(params, "-Xms"+options.HeapSize, "-Xmx"+options.HeapSize)
+	}
+	if len(options.MaxDirectMemorySize) != 0 {
+		params = append(params, "-XX:MaxDirectMemorySize="+options.MaxDirectMemorySize)
+	}
+	params = append(params, options.Options...)
+
+	// Java preferences location is set in default INSTALL4J_ADD_VM_PARAMS of the image and is lost when it is overridden
+	for _, p := range params {
+		if strings.HasPrefix(p, nexusDefaultSpec.NexusJavaPrefsOption) {
+			return strings.Join(params, " ")
+		}
+	}
+	return strings.Join(append(params, nexusDefaultSpec.NexusJavaPrefsOption+"=/nexus-data/javaprefs"), " ")
+}
+
+// GenerateNexusResources returns resources of Nexus container from spec or the default memory request
+func GenerateNexusResources(instance v1alpha1.Nexus) coreV1Api.ResourceRequirements {
+	if instance.Spec.Resources != nil {
+		return *instance.Spec.Resources
+	}
+	return coreV1Api.ResourceRequirements{
+		Requests: map[coreV1Api.ResourceName]resource.Quantity{
+			coreV1Api.ResourceMemory: resource.MustParse(nexusDefaultSpec.NexusMemoryRequest),
+		},
+	}
+}
+
+// GenerateNexusLivenessProbe returns liveness probe of Nexus container with parameters overridden from spec
+func GenerateNexusLivenessProbe(instance v1alpha1.Nexus) *coreV1Api.Probe {
+	return generateProbe(coreV1Api.Probe{
+		FailureThreshold:    5,
+		InitialDelaySeconds: 180,
+		PeriodSeconds:       20,
+		SuccessThreshold:    1,
+		TimeoutSeconds:      1,
+	}, instance.Spec.LivenessProbe)
+}
+
+// GenerateNexusReadinessProbe returns readiness probe of Nexus container with parameters overridden from spec
+func GenerateNexusReadinessProbe(instance v1alpha1.Nexus) *coreV1Api.Probe {
+	return generateProbe(coreV1Api.Probe{
+		FailureThreshold:    3,
+		InitialDelaySeconds: 30,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		TimeoutSeconds:      1,
+	}, instance.Spec.ReadinessProbe)
+}
+
+func generateProbe(probe coreV1Api.Probe, override *v1alpha1.NexusProbe) *coreV1Api.Probe {
+	probe.Handler = coreV1Api.Handler{
+		TCPSocket: &coreV1Api.TCPSocketAction{
+			Port: intstr.FromInt(nexusDefaultSpec.NexusPort),
+		},
+	}
+	if override == nil {
+		return &probe
+	}
+	if override.InitialDelaySeconds != 0 {
+		probe.InitialDelaySeconds = override.InitialDelaySeconds
+	}
+	if override.TimeoutSeconds != 0 {
+		probe.TimeoutSeconds = override.TimeoutSeconds
+	}
+	if override.PeriodSeconds != 0 {
+		probe.PeriodSeconds = override.PeriodSeconds
+	}
+	if override.SuccessThreshold != 0 {
+		probe.SuccessThreshold = override.SuccessThreshold
+	}
+	if override.FailureThreshold != 0 {
+		probe.FailureThreshold = override.FailureThreshold
+	}
+	return &probe
+}
+
+// GetNexusImage returns image of Nexus container. The current version is kept if upgrade to the version
+// from spec has been rejected
+func GetNexusImage(instance v1alpha1.Nexus) string {
+	version := instance.Spec.Version
+	if len(instance.Status.CurrentVersion) != 0 && instance.Status.RejectedVersion == version {
+		version = instance.Status.CurrentVersion
+	}
+	return instance.Spec.Image + ":" + version
+}
+
+// GenerateNexusPodLabels returns labels of Nexus pod: labels from spec and app label which is used by selectors
+func GenerateNexusPodLabels(instance v1alpha1.Nexus) map[string]string {
+	labels := map[string]string{}
+	for k, v := range instance.Spec.PodLabels {
+		labels[k] = v
+	}
+	for k, v := range GenerateLabels(instance.Name) {
+		labels[k] = v
+	}
+	return labels
+}
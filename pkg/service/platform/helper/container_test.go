@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	coreV1Api "k8s.io/api/core/v1"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateNexusEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.NexusSpec
+		want []coreV1Api.EnvVar
+	}{
+		{
+			name: "default context",
+			want: []coreV1Api.EnvVar{{Name: "NEXUS_CONTEXT", Value: "/"}},
+		},
+		{
+			name: "base path and additional variables",
+			spec: v1alpha1.NexusSpec{BasePath: "nexus", Env: []coreV1Api.EnvVar{{Name: "TZ", Value: "UTC"}}},
+			want: []coreV1Api.EnvVar{{Name: "NEXUS_CONTEXT", Value: "nexus"}, {Name: "TZ", Value: "UTC"}},
+		},
+		{
+			name: "JVM options keep default Java preferences location",
+			spec: v1alpha1.NexusSpec{JvmOptions: &v1alpha1.NexusJvmOptions{HeapSize: "2g", MaxDirectMemorySize: "3g"}},
+			want: []coreV1Api.EnvVar{{Name: "NEXUS_CONTEXT", Value: "/"}, {Name: "INSTALL4J_ADD_VM_PARAMS",
+				Value: "-Xms2g -Xmx2g -XX:MaxDirectMemorySize=3g -Djava.util.prefs.userRoot=/nexus-data/javaprefs"}},
+		},
+		{
+			name: "Java preferences location from options",
+			spec: v1alpha1.NexusSpec{JvmOptions: &v1alpha1.NexusJvmOptions{Options: []string{"-Djava.util.prefs.userRoot=/tmp"}}},
+			want: []coreV1Api.EnvVar{{Name: "NEXUS_CONTEXT", Value: "/"}, {Name: "INSTALL4J_ADD_VM_PARAMS",
+				Value: "-Djava.util.prefs.userRoot=/tmp"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateNexusEnv(v1alpha1.Nexus{Spec: tt.spec}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateNexusEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
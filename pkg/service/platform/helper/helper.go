@@ -1,8 +1,14 @@
 package helper
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
 	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reflect"
+	"sort"
 )
 
 const (
@@ -25,6 +31,20 @@ func ContainerInDeployConf(containers []coreV1Api.Container, newContainer coreV1
 	return false
 }
 
+// SetContainer replaces container with the same name or appends it if it is absent.
+// Returns true if containers have been changed
+func SetContainer(containers []coreV1Api.Container, container coreV1Api.Container) ([]coreV1Api.Container, bool) {
+	i := findContainer(containers, container.Name)
+	if i < 0 {
+		return append(containers, container), true
+	}
+	if equality.Semantic.DeepEqual(containers[i], container) {
+		return containers, false
+	}
+	containers[i] = container
+	return containers, true
+}
+
 func PortInService(ports []coreV1Api.ServicePort, newPort coreV1Api.ServicePort) bool {
 	for _, port := range ports {
 		if reflect.DeepEqual(port, newPort) {
@@ -33,3 +53,184 @@ func PortInService(ports []coreV1Api.ServicePort, newPort coreV1Api.ServicePort)
 	}
 	return false
 }
+
+// SetServicePort replaces port with the same name or appends it if it is absent
+func SetServicePort(ports []coreV1Api.ServicePort, port coreV1Api.ServicePort) []coreV1Api.ServicePort {
+	for i := range ports {
+		if ports[i].Name == port.Name {
+			ports[i] = port
+			return ports
+		}
+	}
+	return append(ports, port)
+}
+
+// RemoveServicePort removes port with the given name and reports whether it has been present
+func RemoveServicePort(ports []coreV1Api.ServicePort, name string) ([]coreV1Api.ServicePort, bool) {
+	for i := range ports {
+		if ports[i].Name == name {
+			return append(ports[:i], ports[i+1:]...), true
+		}
+	}
+	return ports, false
+}
+
+// GenerateRegistryPortName returns name of Service port of docker registry connector.
+// Service port name is limited to 15 characters, so repository name can not be used
+func GenerateRegistryPortName(port int32) string {
+	return fmt.Sprintf("docker-%v", port)
+}
+
+const (
+	// podLabelsAnnotationSuffix - annotation on Deployment which keeps keys of pod labels set by operator
+	podLabelsAnnotationSuffix = "pod-labels"
+	// podAnnotationsAnnotationSuffix - annotation on Deployment which keeps keys of pod annotations set by operator
+	podAnnotationsAnnotationSuffix = "pod-annotations"
+)
+
+// UpdatePodTemplate copies the fields managed by operator from desired pod template to the existing one.
+// Containers which are absent in desired template (e.g. Keycloak proxy) are kept as is. Keys of pod labels and
+// annotations set by operator are kept in annotations of owner (Deployment or DeploymentConfig), so the ones which
+// have been removed from spec are pruned. Returns true if existing template or owner annotations have been changed
+func UpdatePodTemplate(owner *metav1.ObjectMeta, existing *coreV1Api.PodTemplateSpec, desired coreV1Api.PodTemplateSpec) bool {
+	changed := false
+	if !equality.Semantic.DeepEqual(existing.Spec.ImagePullSecrets, desired.Spec.ImagePullSecrets) {
+		existing.Spec.ImagePullSecrets = desired.Spec.ImagePullSecrets
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec.NodeSelector, desired.Spec.NodeSelector) {
+		existing.Spec.NodeSelector = desired.Spec.NodeSelector
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec.Tolerations, desired.Spec.Tolerations) {
+		existing.Spec.Tolerations = desired.Spec.Tolerations
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec.Affinity, desired.Spec.Affinity) {
+		existing.Spec.Affinity = desired.Spec.Affinity
+		changed = true
+	}
+	if existing.Spec.PriorityClassName != desired.Spec.PriorityClassName {
+		existing.Spec.PriorityClassName = desired.Spec.PriorityClassName
+		// Priority is resolved from the class by admission controller
+		existing.Spec.Priority = nil
+		changed = true
+	}
+	// Labels and annotations may be added by other tools, e.g. on rollout restart, so only the ones set by operator
+	// are synced
+	if syncOwnedStringMap(owner, podLabelsAnnotationSuffix, &existing.ObjectMeta.Labels, desired.ObjectMeta.Labels) {
+		changed = true
+	}
+	if syncOwnedStringMap(owner, podAnnotationsAnnotationSuffix, &existing.ObjectMeta.Annotations, desired.ObjectMeta.Annotations) {
+		changed = true
+	}
+
+	for _, d := range desired.Spec.Containers {
+		i := findContainer(existing.Spec.Containers, d.Name)
+		if i < 0 {
+			existing.Spec.Containers = append(existing.Spec.Containers, d)
+			changed = true
+			continue
+		}
+		c := &existing.Spec.Containers[i]
+		if c.Image != d.Image {
+			c.Image = d.Image
+			changed = true
+		}
+		if !equality.Semantic.DeepEqual(c.Env, d.Env) {
+			c.Env = d.Env
+			changed = true
+		}
+		if !equality.Semantic.DeepEqual(c.Resources, defaultResources(d.Resources)) {
+			c.Resources = d.Resources
+			changed = true
+		}
+		if d.LivenessProbe != nil && !equality.Semantic.DeepEqual(c.LivenessProbe, d.LivenessProbe) {
+			c.LivenessProbe = d.LivenessProbe
+			changed = true
+		}
+		if d.ReadinessProbe != nil && !equality.Semantic.DeepEqual(c.ReadinessProbe, d.ReadinessProbe) {
+			c.ReadinessProbe = d.ReadinessProbe
+			changed = true
+		}
+	}
+	return changed
+}
+
+func findContainer(containers []coreV1Api.Container, name string) int {
+	for i, c := range containers {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// defaultResources applies defaulting of API server to resources: requests which are not set are equal to limits
+func defaultResources(resources coreV1Api.ResourceRequirements) coreV1Api.ResourceRequirements {
+	result := *resources.DeepCopy()
+	for name, limit := range resources.Limits {
+		if _, ok := result.Requests[name]; ok {
+			continue
+		}
+		if result.Requests == nil {
+			result.Requests = coreV1Api.ResourceList{}
+		}
+		result.Requests[name] = limit
+	}
+	return result
+}
+
+// syncOwnedStringMap merges desired map into the existing one and deletes the keys which have been set before
+// but are not desired anymore. Keys of desired map are saved as JSON list to owner annotation with the given suffix.
+// Returns true if existing map or owner annotation has been changed
+func syncOwnedStringMap(owner *metav1.ObjectMeta, annotationSuffix string, existing *map[string]string, desired map[string]string) bool {
+	key := helper.GenerateAnnotationKey(annotationSuffix)
+	var owned []string
+	if value, ok := owner.Annotations[key]; ok {
+		// Malformed value is overwritten, keys can't be pruned once in that case
+		_ = json.Unmarshal([]byte(value), &owned)
+	}
+	changed := mergeStringMap(existing, desired, owned)
+
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	value, _ := json.Marshal(keys)
+	if owner.Annotations[key] != string(value) {
+		if owner.Annotations == nil {
+			owner.Annotations = map[string]string{}
+		}
+		owner.Annotations[key] = string(value)
+		changed = true
+	}
+	return changed
+}
+
+// mergeStringMap sets values from desired map to the existing one, deletes the owned keys which are absent
+// in desired map and reports whether existing map has been changed
+func mergeStringMap(existing *map[string]string, desired map[string]string, owned []string) bool {
+	changed := false
+	for _, k := range owned {
+		if _, ok := desired[k]; ok {
+			continue
+		}
+		if _, ok := (*existing)[k]; ok {
+			delete(*existing, k)
+			changed = true
+		}
+	}
+	for k, v := range desired {
+		if value, ok := (*existing)[k]; ok && value == v {
+			continue
+		}
+		if *existing == nil {
+			*existing = map[string]string{}
+		}
+		(*existing)[k] = v
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,232 @@
+package helper
+
+import (
+	"github.com/epmd-edp/nexus-operator/v2/pkg/helper"
+	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+)
+
+func TestMergeStringMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    map[string]string
+		desired     map[string]string
+		owned       []string
+		want        map[string]string
+		wantChanged bool
+	}{
+		{
+			name:        "nil existing map",
+			desired:     map[string]string{"app": "nexus"},
+			want:        map[string]string{"app": "nexus"},
+			wantChanged: true,
+		},
+		{
+			name:     "nothing to change",
+			existing: map[string]string{"app": "nexus"},
+			desired:  map[string]string{"app": "nexus"},
+			owned:    []string{"app"},
+			want:     map[string]string{"app": "nexus"},
+		},
+		{
+			name:        "changed value",
+			existing:    map[string]string{"app": "nexus", "team": "a"},
+			desired:     map[string]string{"app": "nexus", "team": "b"},
+			owned:       []string{"app", "team"},
+			want:        map[string]string{"app": "nexus", "team": "b"},
+			wantChanged: true,
+		},
+		{
+			name:        "owned key is pruned",
+			existing:    map[string]string{"app": "nexus", "team": "a"},
+			desired:     map[string]string{"app": "nexus"},
+			owned:       []string{"app", "team"},
+			want:        map[string]string{"app": "nexus"},
+			wantChanged: true,
+		},
+		{
+			name:     "key set by other tool is kept",
+			existing: map[string]string{"app": "nexus", "restartedAt": "now"},
+			desired:  map[string]string{"app": "nexus"},
+			owned:    []string{"app"},
+			want:     map[string]string{"app": "nexus", "restartedAt": "now"},
+		},
+		{
+			name:     "owned key which is already absent",
+			existing: map[string]string{"app": "nexus"},
+			desired:  map[string]string{"app": "nexus"},
+			owned:    []string{"app", "team"},
+			want:     map[string]string{"app": "nexus"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := tt.existing
+			changed := mergeStringMap(&existing, tt.desired, tt.owned)
+			if changed != tt.wantChanged {
+				t.Errorf("mergeStringMap() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(existing, tt.want) {
+				t.Errorf("mergeStringMap() map = %v, want %v", existing, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetContainer(t *testing.T) {
+	nexus := coreV1Api.Container{Name: "nexus", Image: "sonatype/nexus3:3.29.0"}
+	proxy := coreV1Api.Container{Name: "proxy", Image: "gatekeeper:1"}
+	updatedProxy := coreV1Api.Container{Name: "proxy", Image: "gatekeeper:2"}
+
+	tests := []struct {
+		name        string
+		containers  []coreV1Api.Container
+		container   coreV1Api.Container
+		want        []coreV1Api.Container
+		wantChanged bool
+	}{
+		{
+			name:        "absent container is appended",
+			containers:  []coreV1Api.Container{nexus},
+			container:   proxy,
+			want:        []coreV1Api.Container{nexus, proxy},
+			wantChanged: true,
+		},
+		{
+			name:        "changed container is replaced",
+			containers:  []coreV1Api.Container{nexus, proxy},
+			container:   updatedProxy,
+			want:        []coreV1Api.Container{nexus, updatedProxy},
+			wantChanged: true,
+		},
+		{
+			name:       "same container",
+			containers: []coreV1Api.Container{nexus, proxy},
+			container:  proxy,
+			want:       []coreV1Api.Container{nexus, proxy},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := SetContainer(tt.containers, tt.container)
+			if changed != tt.wantChanged {
+				t.Errorf("SetContainer() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetContainer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdatePodTemplate(t *testing.T) {
+	labelsKey := helper.GenerateAnnotationKey(podLabelsAnnotationSuffix)
+	annotationsKey := helper.GenerateAnnotationKey(podAnnotationsAnnotationSuffix)
+	template := func(modify func(*coreV1Api.PodTemplateSpec)) coreV1Api.PodTemplateSpec {
+		spec := coreV1Api.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nexus"}}}
+		spec.Spec.Containers = []coreV1Api.Container{{Name: "nexus", Image: "sonatype/nexus3:3.29.0"}}
+		if modify != nil {
+			modify(&spec)
+		}
+		return spec
+	}
+	newImage := func(spec *coreV1Api.PodTemplateSpec) { spec.Spec.Containers[0].Image = "sonatype/nexus3:3.30.0" }
+	env := func(spec *coreV1Api.PodTemplateSpec) {
+		spec.Spec.Containers[0].Env = []coreV1Api.EnvVar{{Name: "NEXUS_CONTEXT", Value: "nexus"}}
+	}
+	pullSecrets := func(spec *coreV1Api.PodTemplateSpec) {
+		spec.Spec.ImagePullSecrets = []coreV1Api.LocalObjectReference{{Name: "registry"}}
+	}
+	limits := coreV1Api.ResourceList{coreV1Api.ResourceMemory: resource.MustParse("2Gi")}
+	resources := func(spec *coreV1Api.PodTemplateSpec) { spec.Spec.Containers[0].Resources.Limits = limits }
+	defaultedResources := func(spec *coreV1Api.PodTemplateSpec) {
+		spec.Spec.Containers[0].Resources = coreV1Api.ResourceRequirements{Limits: limits, Requests: limits}
+	}
+	probe := func(spec *coreV1Api.PodTemplateSpec) {
+		spec.Spec.Containers[0].ReadinessProbe = &coreV1Api.Probe{InitialDelaySeconds: 60}
+	}
+	scheduling := func(spec *coreV1Api.PodTemplateSpec) {
+		spec.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+		spec.Spec.PriorityClassName = "high"
+	}
+	priority := int32(1000)
+	scheduled := func(spec *coreV1Api.PodTemplateSpec) {
+		spec.Spec.PriorityClassName = "low"
+		spec.Spec.Priority = &priority
+	}
+	teamLabel := func(spec *coreV1Api.PodTemplateSpec) { spec.Labels["team"] = "a" }
+	otherLabel := func(spec *coreV1Api.PodTemplateSpec) { spec.Labels["restartedAt"] = "now" }
+	proxy := func(spec *coreV1Api.PodTemplateSpec) {
+		spec.Spec.Containers = append(spec.Spec.Containers, coreV1Api.Container{Name: "proxy", Image: "gatekeeper:1"})
+	}
+	owned := map[string]string{labelsKey: `["app"]`, annotationsKey: `[]`}
+
+	tests := []struct {
+		name             string
+		ownerAnnotations map[string]string
+		existing         coreV1Api.PodTemplateSpec
+		desired          coreV1Api.PodTemplateSpec
+		want             coreV1Api.PodTemplateSpec
+		wantChanged      bool
+		wantOwnedLabels  string
+	}{
+		{name: "owned keys are recorded on the first update", existing: template(nil), desired: template(nil), want: template(nil),
+			wantChanged: true},
+		{name: "nothing to change", ownerAnnotations: owned, existing: template(nil), desired: template(nil), want: template(nil)},
+		{name: "changed image", ownerAnnotations: owned, existing: template(nil), desired: template(newImage), want: template(newImage),
+			wantChanged: true},
+		{name: "changed env", ownerAnnotations: owned, existing: template(nil), desired: template(env), want: template(env),
+			wantChanged: true},
+		{name: "changed image pull secrets", ownerAnnotations: owned, existing: template(nil), desired: template(pullSecrets),
+			want: template(pullSecrets), wantChanged: true},
+		{name: "changed resources", ownerAnnotations: owned, existing: template(nil), desired: template(resources),
+			want: template(resources), wantChanged: true},
+		{name: "requests defaulted from limits are not a change", ownerAnnotations: owned, existing: template(defaultedResources),
+			desired: template(resources), want: template(defaultedResources)},
+		{name: "changed probe", ownerAnnotations: owned, existing: template(nil), desired: template(probe), want: template(probe),
+			wantChanged: true},
+		{name: "probe absent in desired template is kept", ownerAnnotations: owned, existing: template(probe), desired: template(nil),
+			want: template(probe)},
+		{name: "changed scheduling, priority is resolved again", ownerAnnotations: owned, existing: template(scheduled),
+			desired: template(scheduling), want: template(scheduling), wantChanged: true},
+		{name: "added label", ownerAnnotations: owned, existing: template(nil), desired: template(teamLabel), want: template(teamLabel),
+			wantChanged: true, wantOwnedLabels: `["app","team"]`},
+		{name: "label removed from spec is pruned", ownerAnnotations: map[string]string{labelsKey: `["app","team"]`, annotationsKey: `[]`},
+			existing: template(teamLabel), desired: template(nil), want: template(nil), wantChanged: true},
+		{name: "label of other tool is kept", ownerAnnotations: owned, existing: template(otherLabel), desired: template(nil),
+			want: template(otherLabel)},
+		{name: "absent container is added", ownerAnnotations: owned, existing: template(nil), desired: template(proxy),
+			want: template(proxy), wantChanged: true},
+		{name: "container absent in desired template is kept", ownerAnnotations: owned, existing: template(proxy),
+			desired: template(nil), want: template(proxy)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := metav1.ObjectMeta{}
+			if tt.ownerAnnotations != nil {
+				owner.Annotations = map[string]string{}
+				for k, v := range tt.ownerAnnotations {
+					owner.Annotations[k] = v
+				}
+			}
+			existing := tt.existing
+			changed := UpdatePodTemplate(&owner, &existing, tt.desired)
+			if changed != tt.wantChanged {
+				t.Errorf("UpdatePodTemplate() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(existing, tt.want) {
+				t.Errorf("UpdatePodTemplate() = %+v, want %+v", existing, tt.want)
+			}
+			wantOwnedLabels := tt.wantOwnedLabels
+			if len(wantOwnedLabels) == 0 {
+				wantOwnedLabels = `["app"]`
+			}
+			if got := owner.Annotations[labelsKey]; got != wantOwnedLabels {
+				t.Errorf("UpdatePodTemplate() owned labels = %v, want %v", got, wantOwnedLabels)
+			}
+		})
+	}
+}
@@ -14,20 +14,24 @@ import (
 	"github.com/pkg/errors"
 	"io/ioutil"
 	appsV1Api "k8s.io/api/apps/v1"
+	batchV1Api "k8s.io/api/batch/v1"
 	coreV1Api "k8s.io/api/core/v1"
 	extensionsV1Api "k8s.io/api/extensions/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	appsV1Client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	batchV1Client "k8s.io/client-go/kubernetes/typed/batch/v1"
 	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
 	extensionsV1Client "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
 	"k8s.io/client-go/rest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
@@ -43,6 +47,7 @@ type K8SService struct {
 	JenkinsServiceAccountClient jenkinsV1Client.EdpV1Client
 	k8sUnstructuredClient       client.Client
 	appClient                   appsV1Client.AppsV1Client
+	batchClient                 batchV1Client.BatchV1Client
 	extensionsV1Client          extensionsV1Client.ExtensionsV1beta1Client
 	edpCompClient               edpCompClient.EDPComponentV1Client
 }
@@ -58,39 +63,26 @@ func (s K8SService) IsDeploymentReady(instance v1alpha1.Nexus) (res *bool, err e
 	return
 }
 
-func (s K8SService) AddKeycloakProxyToDeployConf(instance v1alpha1.Nexus, args []string) error {
-	c := coreV1Api.Container{
-		Name:            "keycloak-proxy",
-		Image:           nexusDefaultSpec.NexusKeycloakProxyImage,
-		ImagePullPolicy: coreV1Api.PullIfNotPresent,
-		Ports: []coreV1Api.ContainerPort{
-			{
-				ContainerPort: nexusDefaultSpec.NexusKeycloakProxyPort,
-				Protocol:      coreV1Api.ProtocolTCP,
-			},
-		},
-		TerminationMessagePath:   "/dev/termination-log",
-		TerminationMessagePolicy: coreV1Api.TerminationMessageReadFile,
-		Args:                     args,
-	}
-
+// AddAuthProxyToDeployConf adds authentication proxy sidecar to Nexus Deployment or replaces the existing one
+func (s K8SService) AddAuthProxyToDeployConf(instance v1alpha1.Nexus, container coreV1Api.Container) error {
 	old, err := s.appClient.Deployments(instance.Namespace).Get(instance.Name, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 
-	if platformHelper.ContainerInDeployConf(old.Spec.Template.Spec.Containers, c) {
-		log.V(1).Info("Keycloak proxy is present", "Namespace", instance.Namespace, "Name", instance.Name)
+	containers, changed := platformHelper.SetContainer(old.Spec.Template.Spec.Containers, container)
+	if !changed {
+		log.V(1).Info("Authentication proxy is present", "Namespace", instance.Namespace, "Name", instance.Name)
 		return nil
 	}
-	old.Spec.Template.Spec.Containers = append(old.Spec.Template.Spec.Containers, c)
+	old.Spec.Template.Spec.Containers = containers
 
 	_, err = s.appClient.Deployments(instance.Namespace).Update(old)
 	if err != nil {
 		return err
 	}
 
-	log.Info("Keycloak proxy added.", "Namespace", instance.Namespace, "Name", instance.Name)
+	log.Info("Authentication proxy added.", "Namespace", instance.Namespace, "Name", instance.Name, "Container", container.Name)
 	return nil
 }
 
@@ -129,6 +121,7 @@ func (s K8SService) UpdateExternalTargetPath(instance v1alpha1.Nexus, targetPort
 	return err
 }
 
+// CreateDeployment creates Nexus Deployment or brings the existing one in line with Nexus CR
 func (s K8SService) CreateDeployment(instance v1alpha1.Nexus) error {
 	l := platformHelper.GenerateLabels(instance.Name)
 	var rc int32 = 1
@@ -136,11 +129,6 @@ func (s K8SService) CreateDeployment(instance v1alpha1.Nexus) error {
 	t := true
 	f := false
 
-	nexusContextEnv := "/"
-	if len(instance.Spec.BasePath) != 0 {
-		nexusContextEnv = instance.Spec.BasePath
-	}
-
 	do := &appsV1Api.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      instance.Name,
@@ -157,54 +145,30 @@ func (s K8SService) CreateDeployment(instance v1alpha1.Nexus) error {
 			},
 			Template: coreV1Api.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: l,
+					Labels:      platformHelper.GenerateNexusPodLabels(instance),
+					Annotations: instance.Spec.PodAnnotations,
 				},
 				Spec: coreV1Api.PodSpec{
-					ImagePullSecrets: instance.Spec.ImagePullSecrets,
+					ImagePullSecrets:  instance.Spec.ImagePullSecrets,
+					NodeSelector:      instance.Spec.NodeSelector,
+					Tolerations:       instance.Spec.Tolerations,
+					Affinity:          instance.Spec.Affinity,
+					PriorityClassName: instance.Spec.PriorityClassName,
 					Containers: []coreV1Api.Container{
 						{
 							Name:            instance.Name,
-							Image:           instance.Spec.Image + ":" + instance.Spec.Version,
+							Image:           platformHelper.GetNexusImage(instance),
 							ImagePullPolicy: coreV1Api.PullAlways,
-							Env: []coreV1Api.EnvVar{
-								{
-									Name:  "NEXUS_CONTEXT",
-									Value: nexusContextEnv,
-								},
-							},
+							Env:             platformHelper.GenerateNexusEnv(instance),
 							Ports: []coreV1Api.ContainerPort{
 								{
 									ContainerPort: nexusDefaultSpec.NexusPort,
 								},
 							},
-							LivenessProbe: &coreV1Api.Probe{
-								FailureThreshold:    5,
-								InitialDelaySeconds: 180,
-								PeriodSeconds:       20,
-								SuccessThreshold:    1,
-								Handler: coreV1Api.Handler{
-									TCPSocket: &coreV1Api.TCPSocketAction{
-										Port: intstr.FromInt(nexusDefaultSpec.NexusPort),
-									},
-								},
-							},
-							ReadinessProbe: &coreV1Api.Probe{
-								FailureThreshold:    3,
-								InitialDelaySeconds: 30,
-								PeriodSeconds:       10,
-								SuccessThreshold:    1,
-								Handler: coreV1Api.Handler{
-									TCPSocket: &coreV1Api.TCPSocketAction{
-										Port: intstr.FromInt(nexusDefaultSpec.NexusPort),
-									},
-								},
-							},
+							LivenessProbe:          platformHelper.GenerateNexusLivenessProbe(instance),
+							ReadinessProbe:         platformHelper.GenerateNexusReadinessProbe(instance),
 							TerminationMessagePath: "/dev/termination-log",
-							Resources: coreV1Api.ResourceRequirements{
-								Requests: map[coreV1Api.ResourceName]resource.Quantity{
-									coreV1Api.ResourceMemory: resource.MustParse(nexusDefaultSpec.NexusMemoryRequest),
-								},
-							},
+							Resources:              platformHelper.GenerateNexusResources(instance),
 							SecurityContext: &coreV1Api.SecurityContext{
 								AllowPrivilegeEscalation: &f,
 							},
@@ -257,7 +221,7 @@ func (s K8SService) CreateDeployment(instance v1alpha1.Nexus) error {
 
 	d, err := s.appClient.Deployments(do.Namespace).Get(do.Name, metav1.GetOptions{})
 	if err == nil {
-		return err
+		return s.updateDeployment(d, *do)
 	}
 
 	if !k8serrors.IsNotFound(err) {
@@ -275,6 +239,20 @@ func (s K8SService) CreateDeployment(instance v1alpha1.Nexus) error {
 	return nil
 }
 
+func (s K8SService) updateDeployment(d *appsV1Api.Deployment, desired appsV1Api.Deployment) error {
+	if !platformHelper.UpdatePodTemplate(&d.ObjectMeta, &d.Spec.Template, desired.Spec.Template) {
+		return nil
+	}
+
+	if _, err := s.appClient.Deployments(d.Namespace).Update(d); err != nil {
+		return errors.Wrapf(err, "failed to update Deployment %v", d.Name)
+	}
+
+	log.Info("Deployment has been updated",
+		"Namespace", d.Namespace, "Name", d.Name, "DeploymentName", d.Name)
+	return nil
+}
+
 func (s K8SService) CreateExternalEndpoint(instance v1alpha1.Nexus) error {
 	l := platformHelper.GenerateLabels(instance.Name)
 
@@ -361,6 +339,11 @@ func (s *K8SService) Init(c *rest.Config, Scheme *runtime.Scheme, k8sClient *cli
 		return errors.New("appsV1 client initialization failed")
 	}
 
+	bc, err := batchV1Client.NewForConfig(c)
+	if err != nil {
+		return errors.Wrap(err, "batchV1 client initialization failed")
+	}
+
 	ec, err := extensionsV1Client.NewForConfig(c)
 	if err != nil {
 		return errors.New("extensionsV1beta1 client initialization failed")
@@ -374,6 +357,7 @@ func (s *K8SService) Init(c *rest.Config, Scheme *runtime.Scheme, k8sClient *cli
 	s.k8sUnstructuredClient = *k8sClient
 	s.Scheme = Scheme
 	s.appClient = *ac
+	s.batchClient = *bc
 	s.extensionsV1Client = *ec
 	s.edpCompClient = *edpCl
 	return nil
@@ -383,6 +367,10 @@ func (s K8SService) CreateSecurityContext(instance v1alpha1.Nexus, priority int3
 	return nil
 }
 
+func (s K8SService) DeleteSecurityContext(instance v1alpha1.Nexus) error {
+	return nil
+}
+
 // CreateVolume performs creating PersistentVolumeClaim in K8S
 func (s K8SService) CreateVolume(instance v1alpha1.Nexus) error {
 	labels := platformHelper.GenerateLabels(instance.Name)
@@ -429,6 +417,37 @@ func (s K8SService) CreateVolume(instance v1alpha1.Nexus) error {
 	return nil
 }
 
+// RetainVolumes removes owner references from PersistentVolumeClaims of Nexus, so they are not garbage collected
+// together with Nexus CR
+func (s K8SService) RetainVolumes(instance v1alpha1.Nexus) error {
+	for _, volume := range instance.Spec.Volumes {
+		name := instance.Name + "-" + volume.Name
+		pvc, err := s.CoreClient.PersistentVolumeClaims(instance.Namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if len(pvc.OwnerReferences) == 0 {
+			continue
+		}
+
+		var ownerReferences []metav1.OwnerReference
+		for _, ref := range pvc.OwnerReferences {
+			if ref.UID != instance.UID {
+				ownerReferences = append(ownerReferences, ref)
+			}
+		}
+		pvc.OwnerReferences = ownerReferences
+		if _, err = s.CoreClient.PersistentVolumeClaims(instance.Namespace).Update(pvc); err != nil {
+			return err
+		}
+		log.Info("Volume has been retained", "Namespace", instance.Namespace, "Name", instance.Name, "VolumeName", name)
+	}
+	return nil
+}
+
 //CreateSecret creates secret object in K8s cluster
 func (s K8SService) CreateSecret(instance v1alpha1.Nexus, name string, data map[string][]byte) error {
 	labels := platformHelper.GenerateLabels(instance.Name)
@@ -465,6 +484,35 @@ func (s K8SService) CreateSecret(instance v1alpha1.Nexus, name string, data map[
 	return nil
 }
 
+// AnnotateServiceAccount sets annotations on service account of Nexus
+func (s K8SService) AnnotateServiceAccount(instance v1alpha1.Nexus, annotations map[string]string) error {
+	svcAcc, err := s.CoreClient.ServiceAccounts(instance.Namespace).Get(instance.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for k, v := range annotations {
+		if value, ok := svcAcc.Annotations[k]; ok && value == v {
+			continue
+		}
+		if svcAcc.Annotations == nil {
+			svcAcc.Annotations = map[string]string{}
+		}
+		svcAcc.Annotations[k] = v
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if _, err = s.CoreClient.ServiceAccounts(instance.Namespace).Update(svcAcc); err != nil {
+		return err
+	}
+	log.Info("ServiceAccount has been annotated", "Namespace", instance.Namespace, "Name", instance.Name, "ServiceAccountName", svcAcc.Name)
+	return nil
+}
+
 // CreateServiceAccount performs creating ServiceAccount in K8S
 func (s K8SService) CreateServiceAccount(instance v1alpha1.Nexus) error {
 	labels := platformHelper.GenerateLabels(instance.Name)
@@ -524,7 +572,8 @@ func (s K8SService) AddPortToService(instance v1alpha1.Nexus, newPortSpec coreV1
 		return nil
 	}
 
-	svc.Spec.Ports = append(svc.Spec.Ports, newPortSpec)
+	// Port with the same name is replaced, e.g. when authentication proxy listens on another port
+	svc.Spec.Ports = platformHelper.SetServicePort(svc.Spec.Ports, newPortSpec)
 
 	if _, err = s.CoreClient.Services(instance.Namespace).Update(svc); err != nil {
 		return err
@@ -532,6 +581,130 @@ func (s K8SService) AddPortToService(instance v1alpha1.Nexus, newPortSpec coreV1
 	return nil
 }
 
+// RemovePortFromService removes port with the given name from Nexus Service
+func (s K8SService) RemovePortFromService(instance v1alpha1.Nexus, name string) error {
+	svc, err := s.GetServiceByCr(instance.Name, instance.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "couldn't get service")
+	}
+
+	ports, removed := platformHelper.RemoveServicePort(svc.Spec.Ports, name)
+	if !removed {
+		return nil
+	}
+	svc.Spec.Ports = ports
+	_, err = s.CoreClient.Services(instance.Namespace).Update(svc)
+	return err
+}
+
+// ExposeRegistry adds connector port of docker registry to Nexus Service and creates or updates its Ingress.
+// Port of the previous connector is removed from Service if it has been changed
+func (s K8SService) ExposeRegistry(instance v1alpha1.Nexus, name string, host string, port int32) error {
+	portName := platformHelper.GenerateRegistryPortName(port)
+	desired := &extensionsV1Api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    platformHelper.GenerateLabels(instance.Name),
+			Annotations: map[string]string{
+				// Image layers are not limited in size
+				"nginx.ingress.kubernetes.io/proxy-body-size": "0",
+			},
+		},
+		Spec: extensionsV1Api.IngressSpec{
+			Rules: []extensionsV1Api.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: extensionsV1Api.IngressRuleValue{
+						HTTP: &extensionsV1Api.HTTPIngressRuleValue{
+							Paths: []extensionsV1Api.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: extensionsV1Api.IngressBackend{
+										ServiceName: instance.Name,
+										ServicePort: intstr.FromString(portName),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(&instance, desired, s.Scheme); err != nil {
+		return err
+	}
+
+	existing, err := s.extensionsV1Client.Ingresses(instance.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+	if found {
+		if previous := getIngressPortName(*existing); previous != portName {
+			if err := s.RemovePortFromService(instance, previous); err != nil {
+				return errors.Wrapf(err, "failed to remove port %v from service", previous)
+			}
+		}
+	}
+
+	err = s.AddPortToService(instance, coreV1Api.ServicePort{
+		Name:       portName,
+		Protocol:   coreV1Api.ProtocolTCP,
+		Port:       port,
+		TargetPort: intstr.FromInt(int(port)),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to add port %v to service", portName)
+	}
+
+	if !found {
+		if _, err = s.extensionsV1Client.Ingresses(instance.Namespace).Create(desired); err != nil {
+			return err
+		}
+		log.Info("Registry Ingress has been created", "Namespace", instance.Namespace, "Name", instance.Name, "IngressName", name)
+		return nil
+	}
+
+	if reflect.DeepEqual(existing.Spec.Rules, desired.Spec.Rules) &&
+		reflect.DeepEqual(existing.Annotations, desired.Annotations) {
+		return nil
+	}
+	existing.Spec.Rules = desired.Spec.Rules
+	existing.Annotations = desired.Annotations
+	_, err = s.extensionsV1Client.Ingresses(instance.Namespace).Update(existing)
+	return err
+}
+
+// RemoveRegistry deletes Ingress of docker registry and removes its connector port from Nexus Service
+func (s K8SService) RemoveRegistry(instance v1alpha1.Nexus, name string) error {
+	existing, err := s.extensionsV1Client.Ingresses(instance.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.RemovePortFromService(instance, getIngressPortName(*existing)); err != nil {
+		return errors.Wrap(err, "failed to remove registry port from service")
+	}
+	err = s.extensionsV1Client.Ingresses(instance.Namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	log.Info("Registry Ingress has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "IngressName", name)
+	return nil
+}
+
+func getIngressPortName(ingress extensionsV1Api.Ingress) string {
+	if len(ingress.Spec.Rules) == 0 || ingress.Spec.Rules[0].HTTP == nil || len(ingress.Spec.Rules[0].HTTP.Paths) == 0 {
+		return ""
+	}
+	return ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServicePort.StrVal
+}
+
 // CreateService performs creating Service in K8S
 func (s K8SService) CreateService(instance v1alpha1.Nexus) error {
 	labels := platformHelper.GenerateLabels(instance.Name)
@@ -731,6 +904,26 @@ func (s K8SService) CreateJenkinsServiceAccount(namespace string, secretName str
 	return nil
 }
 
+// DeleteJenkinsServiceAccount deletes JenkinsServiceAccount if it exists
+func (s K8SService) DeleteJenkinsServiceAccount(namespace string, name string) error {
+	jsa := &jenkinsV1Api.JenkinsServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	err := s.k8sUnstructuredClient.Delete(context.TODO(), jsa)
+	if err != nil {
+		// CRD may be missing in the cluster if the integration has never been used
+		if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete Jenkins service account %s", name)
+	}
+	log.Info("JenkinsServiceAccount has been deleted", "Namespace", namespace, "JenkinsServiceAccountName", name)
+	return nil
+}
+
 func (s K8SService) CreateKeycloakClient(kc *keycloakV1Api.KeycloakClient) error {
 	nsn := types.NamespacedName{
 		Namespace: kc.Namespace,
@@ -756,6 +949,15 @@ func (s K8SService) CreateKeycloakClient(kc *keycloakV1Api.KeycloakClient) error
 	return nil
 }
 
+// UpdateKeycloakClient updates existing KeycloakClient
+func (s K8SService) UpdateKeycloakClient(kc *keycloakV1Api.KeycloakClient) error {
+	if err := s.k8sUnstructuredClient.Update(context.TODO(), kc); err != nil {
+		return errors.Wrapf(err, "failed to update Keycloak client %s", kc.Name)
+	}
+	log.Info("Keycloak client updated", "Namespace", kc.Namespace, "Name", kc.Name, "KeycloakClientName", kc.Name)
+	return nil
+}
+
 func (s K8SService) GetKeycloakClient(name string, namespace string) (keycloakV1Api.KeycloakClient, error) {
 	out := keycloakV1Api.KeycloakClient{}
 	nsn := types.NamespacedName{
@@ -771,6 +973,26 @@ func (s K8SService) GetKeycloakClient(name string, namespace string) (keycloakV1
 	return out, nil
 }
 
+// DeleteKeycloakClient deletes KeycloakClient if it exists
+func (s K8SService) DeleteKeycloakClient(name string, namespace string) error {
+	kc := &keycloakV1Api.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	err := s.k8sUnstructuredClient.Delete(context.TODO(), kc)
+	if err != nil {
+		// CRD may be missing in the cluster if the integration has never been used
+		if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete Keycloak client %s", name)
+	}
+	log.Info("Keycloak client deleted", "Namespace", namespace, "KeycloakClientName", name)
+	return nil
+}
+
 func (s K8SService) GetIngressByCr(instance v1alpha1.Nexus) (*extensionsV1Api.Ingress, error) {
 	i, err := s.extensionsV1Client.Ingresses(instance.Namespace).List(metav1.ListOptions{})
 	if err != nil {
@@ -818,3 +1040,177 @@ func (s K8SService) createEDPComponent(nexus v1alpha1.Nexus, url string, icon st
 		Create(obj)
 	return err
 }
+
+// getBackupCommand returns command which archives Nexus data and removes old archives of Nexus from backup volume.
+// Archive is written under temporary name so that incomplete archive doesn't push out the complete ones.
+// Blob stores outside of data volume (e.g. S3) are not archived
+func getBackupCommand(instance v1alpha1.Nexus, archiveName string) string {
+	return fmt.Sprintf("tar czf /backup/%[2]v.tmp -C /nexus-data db blobs && mv /backup/%[2]v.tmp /backup/%[2]v && "+
+		"ls -1t /backup/%[1]v-*.tar.gz | tail -n +%[3]v | xargs -r rm -f",
+		instance.Name, archiveName, nexusDefaultSpec.NexusBackupArchivesToKeep+1)
+}
+
+// CreateBackupJob creates PersistentVolumeClaim for backups and Job which archives Nexus data into it.
+// The Job is scheduled on the node of Nexus pod since data volume may be mounted only by one node
+func (s K8SService) CreateBackupJob(instance v1alpha1.Nexus, name string, archiveName string) error {
+	l := platformHelper.GenerateLabels(instance.Name)
+	backupLabels := platformHelper.GenerateLabels(fmt.Sprintf("%v-backup", instance.Name))
+
+	if err := s.createBackupVolume(instance, backupLabels); err != nil {
+		return err
+	}
+
+	_, err := s.batchClient.Jobs(instance.Namespace).Get(name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	var backoffLimit int32 = 1
+	var fsg int64 = 200
+	t := true
+	jo := &batchV1Api.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    backupLabels,
+		},
+		Spec: batchV1Api.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: coreV1Api.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: backupLabels,
+				},
+				Spec: coreV1Api.PodSpec{
+					RestartPolicy:      coreV1Api.RestartPolicyNever,
+					ServiceAccountName: instance.Name,
+					ImagePullSecrets:   instance.Spec.ImagePullSecrets,
+					Tolerations:        instance.Spec.Tolerations,
+					Affinity: &coreV1Api.Affinity{
+						PodAffinity: &coreV1Api.PodAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: []coreV1Api.PodAffinityTerm{
+								{
+									LabelSelector: &metav1.LabelSelector{MatchLabels: l},
+									TopologyKey:   "kubernetes.io/hostname",
+								},
+							},
+						},
+					},
+					SecurityContext: &coreV1Api.PodSecurityContext{
+						FSGroup:      &fsg,
+						RunAsNonRoot: &t,
+						RunAsUser:    &fsg,
+						RunAsGroup:   &fsg,
+					},
+					Containers: []coreV1Api.Container{
+						{
+							Name:    "backup",
+							Image:   nexusDefaultSpec.NexusBackupImage,
+							Command: []string{"sh", "-c", getBackupCommand(instance, archiveName)},
+							VolumeMounts: []coreV1Api.VolumeMount{
+								{
+									MountPath: "/nexus-data",
+									Name:      "data",
+									ReadOnly:  true,
+								},
+								{
+									MountPath: "/backup",
+									Name:      "backup",
+								},
+							},
+						},
+					},
+					Volumes: []coreV1Api.Volume{
+						{
+							Name: "data",
+							VolumeSource: coreV1Api.VolumeSource{
+								PersistentVolumeClaim: &coreV1Api.PersistentVolumeClaimVolumeSource{
+									ClaimName: fmt.Sprintf("%v-data", instance.Name),
+									ReadOnly:  true,
+								},
+							},
+						},
+						{
+							Name: "backup",
+							VolumeSource: coreV1Api.VolumeSource{
+								PersistentVolumeClaim: &coreV1Api.PersistentVolumeClaimVolumeSource{
+									ClaimName: fmt.Sprintf("%v-backup", instance.Name),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(&instance, jo, s.Scheme); err != nil {
+		return err
+	}
+
+	j, err := s.batchClient.Jobs(instance.Namespace).Create(jo)
+	if err != nil {
+		return err
+	}
+	log.Info("Backup Job has been created", "Namespace", instance.Namespace, "Name", instance.Name, "JobName", j.Name)
+	return nil
+}
+
+// createBackupVolume creates PersistentVolumeClaim for backups with the size and storage class of data volume.
+// The claim isn't owned by Nexus CR so that backups outlive it
+func (s K8SService) createBackupVolume(instance v1alpha1.Nexus, labels map[string]string) error {
+	var dataVolume *v1alpha1.NexusVolumes
+	for i, v := range instance.Spec.Volumes {
+		if v.Name == "data" {
+			dataVolume = &instance.Spec.Volumes[i]
+		}
+	}
+	if dataVolume == nil {
+		return errors.New("data volume is not defined in Nexus spec")
+	}
+
+	name := fmt.Sprintf("%v-backup", instance.Name)
+	_, err := s.CoreClient.PersistentVolumeClaims(instance.Namespace).Get(name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	vo := &coreV1Api.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: coreV1Api.PersistentVolumeClaimSpec{
+			AccessModes: []coreV1Api.PersistentVolumeAccessMode{
+				coreV1Api.ReadWriteOnce,
+			},
+			StorageClassName: &dataVolume.StorageClass,
+			Resources: coreV1Api.ResourceRequirements{
+				Requests: map[coreV1Api.ResourceName]resource.Quantity{
+					coreV1Api.ResourceStorage: resource.MustParse(dataVolume.Capacity),
+				},
+			},
+		},
+	}
+
+	v, err := s.CoreClient.PersistentVolumeClaims(instance.Namespace).Create(vo)
+	if err != nil {
+		return err
+	}
+	log.Info("Backup volume has been created", "Namespace", instance.Namespace, "Name", instance.Name, "VolumeName", v.Name)
+	return nil
+}
+
+// GetJobStatus returns status of Job
+func (s K8SService) GetJobStatus(namespace string, name string) (*batchV1Api.JobStatus, error) {
+	j, err := s.batchClient.Jobs(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &j.Status, nil
+}
@@ -3,6 +3,7 @@ package openshift
 import (
 	"fmt"
 	"github.com/epmd-edp/nexus-operator/v2/pkg/apis/edp/v1alpha1"
+	controllerHelper "github.com/epmd-edp/nexus-operator/v2/pkg/controller/helper"
 	nexusDefaultSpec "github.com/epmd-edp/nexus-operator/v2/pkg/service/nexus/spec"
 	platformHelper "github.com/epmd-edp/nexus-operator/v2/pkg/service/platform/helper"
 	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform/kubernetes"
@@ -15,7 +16,6 @@ import (
 	"github.com/pkg/errors"
 	coreV1Api "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -111,9 +111,7 @@ func (service OpenshiftService) CreateSecurityContext(instance v1alpha1.Nexus, p
 			Type:   securityV1Api.SupplementalGroupsStrategyRunAsAny,
 			Ranges: nil,
 		},
-		Users: []string{
-			fmt.Sprintf("system:serviceaccount:%s:%s", instance.Namespace, instance.Name),
-		},
+		Users: []string{getSecurityContextUser(instance)},
 	}
 
 	scc, err := service.securityClient.SecurityContextConstraints().Get(sccObject.Name, metav1.GetOptions{})
@@ -128,8 +126,12 @@ func (service OpenshiftService) CreateSecurityContext(instance v1alpha1.Nexus, p
 		}
 		return err
 	}
-	if !reflect.DeepEqual(scc.Users, sccObject.Users) {
-		scc, err = service.securityClient.SecurityContextConstraints().Update(sccObject)
+	// Security Context Constraint is cluster-scoped, so it is shared by Nexus instances with the same name
+	// in different namespaces
+	user := getSecurityContextUser(instance)
+	if !controllerHelper.ContainsString(scc.Users, user) {
+		scc.Users = append(scc.Users, user)
+		scc, err = service.securityClient.SecurityContextConstraints().Update(scc)
 		if err != nil {
 			return err
 		}
@@ -139,53 +141,83 @@ func (service OpenshiftService) CreateSecurityContext(instance v1alpha1.Nexus, p
 	return nil
 }
 
-func (service OpenshiftService) AddKeycloakProxyToDeployConf(instance v1alpha1.Nexus, args []string) error {
+// getSecurityContextUser returns service account of Nexus in the form of Security Context Constraint user
+func getSecurityContextUser(instance v1alpha1.Nexus) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", instance.Namespace, instance.Name)
+}
 
-	containerSpec := coreV1Api.Container{
-		Name:            "keycloak-proxy",
-		Image:           nexusDefaultSpec.NexusKeycloakProxyImage,
-		ImagePullPolicy: coreV1Api.PullIfNotPresent,
-		Ports: []coreV1Api.ContainerPort{
-			{
-				ContainerPort: nexusDefaultSpec.NexusKeycloakProxyPort,
-				Protocol:      coreV1Api.ProtocolTCP,
-			},
-		},
-		TerminationMessagePath:   "/dev/termination-log",
-		TerminationMessagePolicy: coreV1Api.TerminationMessageReadFile,
-		Args:                     args,
+// DeleteSecurityContext removes service account of Nexus from Security Context Constraint. The constraint is deleted
+// only when it is not used by Nexus instances from other namespaces
+func (service OpenshiftService) DeleteSecurityContext(instance v1alpha1.Nexus) error {
+	scc, err := service.securityClient.SecurityContextConstraints().Get(instance.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	user := getSecurityContextUser(instance)
+	if !controllerHelper.ContainsString(scc.Users, user) {
+		log.Info(fmt.Sprintf("Security Context Constraint %s is not used by Nexus, it is kept", scc.Name),
+			"Namespace", instance.Namespace, "Name", instance.Name)
+		return nil
+	}
+
+	if len(scc.Users) > 1 {
+		var users []string
+		for _, u := range scc.Users {
+			if u != user {
+				users = append(users, u)
+			}
+		}
+		scc.Users = users
+		if _, err = service.securityClient.SecurityContextConstraints().Update(scc); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Service account has been removed from Security Context Constraint %s", scc.Name),
+			"Namespace", instance.Namespace, "Name", instance.Name)
+		return nil
 	}
 
+	err = service.securityClient.SecurityContextConstraints().Delete(instance.Name, &metav1.DeleteOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	log.Info(fmt.Sprintf("Security Context Constraint %s has been deleted", instance.Name))
+	return nil
+}
+
+// AddAuthProxyToDeployConf adds authentication proxy sidecar to Nexus DeploymentConfig or replaces the existing one
+func (service OpenshiftService) AddAuthProxyToDeployConf(instance v1alpha1.Nexus, container coreV1Api.Container) error {
 	oldNexusDeploymentConfig, err := service.appClient.DeploymentConfigs(instance.Namespace).Get(instance.Name, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 
-	if platformHelper.ContainerInDeployConf(oldNexusDeploymentConfig.Spec.Template.Spec.Containers, containerSpec) {
-		log.V(1).Info("Keycloak proxy is present", "Namespace", instance.Namespace, "Name", instance.Name)
+	containers, changed := platformHelper.SetContainer(oldNexusDeploymentConfig.Spec.Template.Spec.Containers, container)
+	if !changed {
+		log.V(1).Info("Authentication proxy is present", "Namespace", instance.Namespace, "Name", instance.Name)
 		return nil
 	}
-	oldNexusDeploymentConfig.Spec.Template.Spec.Containers = append(oldNexusDeploymentConfig.Spec.Template.Spec.Containers, containerSpec)
+	oldNexusDeploymentConfig.Spec.Template.Spec.Containers = containers
 
 	_, err = service.appClient.DeploymentConfigs(instance.Namespace).Update(oldNexusDeploymentConfig)
 	if err != nil {
 		return err
 	}
 
-	log.Info("Keycloak proxy added.", "Namespace", instance.Namespace, "Name", instance.Name)
+	log.Info("Authentication proxy added.", "Namespace", instance.Namespace, "Name", instance.Name, "Container", container.Name)
 	return nil
-
 }
 
-// CreateDeployment performs creating DeploymentConfig in Openshift
+// CreateDeployment creates Nexus DeploymentConfig or brings the existing one in line with Nexus CR
 func (service OpenshiftService) CreateDeployment(instance v1alpha1.Nexus) error {
 	labels := platformHelper.GenerateLabels(instance.Name)
 
-	nexusContextEnv := "/"
-	if len(instance.Spec.BasePath) != 0 {
-		nexusContextEnv = instance.Spec.BasePath
-	}
-
 	deploymentConfigObject := &appsV1Api.DeploymentConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      instance.Name,
@@ -205,54 +237,30 @@ func (service OpenshiftService) CreateDeployment(instance v1alpha1.Nexus) error
 			Selector: labels,
 			Template: &coreV1Api.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      platformHelper.GenerateNexusPodLabels(instance),
+					Annotations: instance.Spec.PodAnnotations,
 				},
 				Spec: coreV1Api.PodSpec{
-					ImagePullSecrets: instance.Spec.ImagePullSecrets,
+					ImagePullSecrets:  instance.Spec.ImagePullSecrets,
+					NodeSelector:      instance.Spec.NodeSelector,
+					Tolerations:       instance.Spec.Tolerations,
+					Affinity:          instance.Spec.Affinity,
+					PriorityClassName: instance.Spec.PriorityClassName,
 					Containers: []coreV1Api.Container{
 						{
 							Name:            instance.Name,
-							Image:           instance.Spec.Image + ":" + instance.Spec.Version,
+							Image:           platformHelper.GetNexusImage(instance),
 							ImagePullPolicy: coreV1Api.PullAlways,
-							Env: []coreV1Api.EnvVar{
-								{
-									Name:  "NEXUS_CONTEXT",
-									Value: nexusContextEnv,
-								},
-							},
+							Env:             platformHelper.GenerateNexusEnv(instance),
 							Ports: []coreV1Api.ContainerPort{
 								{
 									ContainerPort: nexusDefaultSpec.NexusPort,
 								},
 							},
-							LivenessProbe: &coreV1Api.Probe{
-								FailureThreshold:    5,
-								InitialDelaySeconds: 180,
-								PeriodSeconds:       20,
-								SuccessThreshold:    1,
-								Handler: coreV1Api.Handler{
-									TCPSocket: &coreV1Api.TCPSocketAction{
-										Port: intstr.FromInt(nexusDefaultSpec.NexusPort),
-									},
-								},
-							},
-							ReadinessProbe: &coreV1Api.Probe{
-								FailureThreshold:    3,
-								InitialDelaySeconds: 30,
-								PeriodSeconds:       10,
-								SuccessThreshold:    1,
-								Handler: coreV1Api.Handler{
-									TCPSocket: &coreV1Api.TCPSocketAction{
-										Port: intstr.FromInt(nexusDefaultSpec.NexusPort),
-									},
-								},
-							},
+							LivenessProbe:          platformHelper.GenerateNexusLivenessProbe(instance),
+							ReadinessProbe:         platformHelper.GenerateNexusReadinessProbe(instance),
 							TerminationMessagePath: "/dev/termination-log",
-							Resources: coreV1Api.ResourceRequirements{
-								Requests: map[coreV1Api.ResourceName]resource.Quantity{
-									coreV1Api.ResourceMemory: resource.MustParse(nexusDefaultSpec.NexusMemoryRequest),
-								},
-							},
+							Resources:              platformHelper.GenerateNexusResources(instance),
 							VolumeMounts: []coreV1Api.VolumeMount{
 								{
 									MountPath: "/nexus-data",
@@ -295,7 +303,7 @@ func (service OpenshiftService) CreateDeployment(instance v1alpha1.Nexus) error
 
 	deploymentConfig, err := service.appClient.DeploymentConfigs(deploymentConfigObject.Namespace).Get(deploymentConfigObject.Name, metav1.GetOptions{})
 	if err == nil {
-		return err
+		return service.updateDeploymentConfig(deploymentConfig, *deploymentConfigObject)
 	}
 
 	if !k8serrors.IsNotFound(err) {
@@ -312,6 +320,21 @@ func (service OpenshiftService) CreateDeployment(instance v1alpha1.Nexus) error
 	return nil
 }
 
+func (service OpenshiftService) updateDeploymentConfig(dc *appsV1Api.DeploymentConfig, desired appsV1Api.DeploymentConfig) error {
+	if dc.Spec.Template == nil {
+		dc.Spec.Template = desired.Spec.Template
+	} else if !platformHelper.UpdatePodTemplate(&dc.ObjectMeta, dc.Spec.Template, *desired.Spec.Template) {
+		return nil
+	}
+
+	if _, err := service.appClient.DeploymentConfigs(dc.Namespace).Update(dc); err != nil {
+		return errors.Wrapf(err, "failed to update DeploymentConfig %v", dc.Name)
+	}
+
+	log.Info("DeploymentConfig has been updated", "Namespace", dc.Namespace, "Name", dc.Name, "DeploymentName", dc.Name)
+	return nil
+}
+
 // CreateExternalEndpoint performs creating Route in Openshift
 func (service OpenshiftService) CreateExternalEndpoint(instance v1alpha1.Nexus) error {
 	labels := platformHelper.GenerateLabels(instance.Name)
@@ -366,6 +389,101 @@ func (service OpenshiftService) CreateExternalEndpoint(instance v1alpha1.Nexus)
 	return nil
 }
 
+// ExposeRegistry adds connector port of docker registry to Nexus Service and creates or updates its Route.
+// Port of the previous connector is removed from Service if it has been changed
+func (service OpenshiftService) ExposeRegistry(instance v1alpha1.Nexus, name string, host string, port int32) error {
+	portName := platformHelper.GenerateRegistryPortName(port)
+	desired := &routeV1Api.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    platformHelper.GenerateLabels(instance.Name),
+		},
+		Spec: routeV1Api.RouteSpec{
+			Host: host,
+			TLS: &routeV1Api.TLSConfig{
+				Termination:                   routeV1Api.TLSTerminationEdge,
+				InsecureEdgeTerminationPolicy: routeV1Api.InsecureEdgeTerminationPolicyRedirect,
+			},
+			To: routeV1Api.RouteTargetReference{
+				Name: instance.Name,
+				Kind: "Service",
+			},
+			Port: &routeV1Api.RoutePort{TargetPort: intstr.FromString(portName)},
+		},
+	}
+	if err := controllerutil.SetControllerReference(&instance, desired, service.Scheme); err != nil {
+		return err
+	}
+
+	existing, err := service.routeClient.Routes(instance.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+	if found {
+		if previous := getRoutePortName(*existing); previous != portName {
+			if err := service.RemovePortFromService(instance, previous); err != nil {
+				return errors.Wrapf(err, "failed to remove port %v from service", previous)
+			}
+		}
+	}
+
+	err = service.AddPortToService(instance, coreV1Api.ServicePort{
+		Name:       portName,
+		Protocol:   coreV1Api.ProtocolTCP,
+		Port:       port,
+		TargetPort: intstr.FromInt(int(port)),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to add port %v to service", portName)
+	}
+
+	if !found {
+		if _, err = service.routeClient.Routes(instance.Namespace).Create(desired); err != nil {
+			return err
+		}
+		log.Info("Registry Route has been created", "Namespace", instance.Namespace, "Name", instance.Name, "RouteName", name)
+		return nil
+	}
+
+	if existing.Spec.Host == desired.Spec.Host && reflect.DeepEqual(existing.Spec.Port, desired.Spec.Port) {
+		return nil
+	}
+	existing.Spec.Host = desired.Spec.Host
+	existing.Spec.Port = desired.Spec.Port
+	_, err = service.routeClient.Routes(instance.Namespace).Update(existing)
+	return err
+}
+
+// RemoveRegistry deletes Route of docker registry and removes its connector port from Nexus Service
+func (service OpenshiftService) RemoveRegistry(instance v1alpha1.Nexus, name string) error {
+	existing, err := service.routeClient.Routes(instance.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := service.RemovePortFromService(instance, getRoutePortName(*existing)); err != nil {
+		return errors.Wrap(err, "failed to remove registry port from service")
+	}
+	err = service.routeClient.Routes(instance.Namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	log.Info("Registry Route has been deleted", "Namespace", instance.Namespace, "Name", instance.Name, "RouteName", name)
+	return nil
+}
+
+func getRoutePortName(route routeV1Api.Route) string {
+	if route.Spec.Port == nil {
+		return ""
+	}
+	return route.Spec.Port.TargetPort.StrVal
+}
+
 // GetExternalUrl returns Web URL for object and scheme from Openshift Route
 func (service OpenshiftService) GetExternalUrl(namespace string, name string) (webURL, host string, scheme string, err error) {
 	route, err := service.routeClient.Routes(namespace).Get(name, metav1.GetOptions{})
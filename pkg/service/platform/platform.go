@@ -8,6 +8,7 @@ import (
 	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform/kubernetes"
 	"github.com/epmd-edp/nexus-operator/v2/pkg/service/platform/openshift"
 	"github.com/pkg/errors"
+	batchV1Api "k8s.io/api/batch/v1"
 	coreV1Api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -23,7 +24,7 @@ const (
 
 // PlatformService interface
 type PlatformService interface {
-	AddKeycloakProxyToDeployConf(instance v1alpha1.Nexus, args []string) error
+	AddAuthProxyToDeployConf(instance v1alpha1.Nexus, container coreV1Api.Container) error
 	GetExternalUrl(namespace string, name string) (webURL string, host string, scheme string, err error)
 	UpdateExternalTargetPath(instance v1alpha1.Nexus, targetPort intstr.IntOrString) error
 	GetConfigMapData(namespace string, name string) (map[string]string, error)
@@ -33,19 +34,30 @@ type PlatformService interface {
 	CreateService(instance v1alpha1.Nexus) error
 	GetServiceByCr(name, namespace string) (*coreV1Api.Service, error)
 	AddPortToService(instance v1alpha1.Nexus, newPortSpec coreV1Api.ServicePort) error
+	RemovePortFromService(instance v1alpha1.Nexus, name string) error
+	ExposeRegistry(instance v1alpha1.Nexus, name string, host string, port int32) error
+	RemoveRegistry(instance v1alpha1.Nexus, name string) error
 	CreateVolume(instance v1alpha1.Nexus) error
 	CreateServiceAccount(instance v1alpha1.Nexus) error
+	AnnotateServiceAccount(instance v1alpha1.Nexus, annotations map[string]string) error
 	CreateConfigMapFromFile(instance v1alpha1.Nexus, configMapName string, filePath string) error
 	CreateConfigMapsFromDirectory(instance v1alpha1.Nexus, directoryPath string, createDedicatedConfigMaps bool) error
 	CreateDeployment(instance v1alpha1.Nexus) error
 	CreateExternalEndpoint(instance v1alpha1.Nexus) error
 	CreateSecurityContext(ac v1alpha1.Nexus, priority int32) error
+	DeleteSecurityContext(instance v1alpha1.Nexus) error
+	RetainVolumes(instance v1alpha1.Nexus) error
 	GetSecret(namespace string, name string) (*coreV1Api.Secret, error)
 	UpdateSecret(secret *coreV1Api.Secret) error
 	CreateJenkinsServiceAccount(namespace string, secretName string) error
+	DeleteJenkinsServiceAccount(namespace string, name string) error
 	CreateKeycloakClient(kc *keycloakV1Api.KeycloakClient) error
 	GetKeycloakClient(name string, namespace string) (keycloakV1Api.KeycloakClient, error)
+	UpdateKeycloakClient(kc *keycloakV1Api.KeycloakClient) error
+	DeleteKeycloakClient(name string, namespace string) error
 	CreateEDPComponentIfNotExist(instance v1alpha1.Nexus, url string, icon string) error
+	CreateBackupJob(instance v1alpha1.Nexus, name string, archiveName string) error
+	GetJobStatus(namespace string, name string) (*batchV1Api.JobStatus, error)
 }
 
 // NewPlatformService returns platform service interface implementation